@@ -1,19 +1,409 @@
 package game
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+)
 
 func TestApplyBossEffect(t *testing.T) {
 	g := &Game{currentTarget: 100, money: 200}
 
-	g.currentBoss = Boss{Effect: DoubleChips}
+	g.currentBoss = Boss{Effects: []BossEffectConfig{{Effect: DoubleChips}}}
 	g.applyBossEffect()
 	if g.currentTarget != 200 {
 		t.Errorf("expected target 200, got %d", g.currentTarget)
 	}
 
-	g.currentBoss = Boss{Effect: HalveMoney}
+	g.currentBoss = Boss{Effects: []BossEffectConfig{{Effect: HalveMoney}}}
 	g.applyBossEffect()
 	if g.money != 100 {
 		t.Errorf("expected money 100, got %d", g.money)
 	}
 }
+
+// TestComposedBossEffects verifies a boss applies every effect in its list.
+func TestComposedBossEffects(t *testing.T) {
+	g := &Game{
+		currentBlind:  BossBlind,
+		currentTarget: 100,
+		money:         20,
+		currentBoss: Boss{Name: "The Tyrant", Effects: []BossEffectConfig{
+			{Effect: DoubleChips},
+			{Effect: HalveMoney},
+			{Effect: ChangeHandSize, Magnitude: -2},
+			{Effect: DebuffSuit, Suit: "Spades"},
+		}},
+	}
+	g.applyBossEffect()
+
+	if g.currentTarget != 200 || g.money != 10 {
+		t.Fatalf("expected target 200 and money 10, got %d and %d", g.currentTarget, g.money)
+	}
+	if got := g.handSize(); got != InitialCards-2 {
+		t.Fatalf("expected hand size %d, got %d", InitialCards-2, got)
+	}
+	if !g.isCardDebuffed(Card{Rank: Ten, Suit: Spades}) || g.isCardDebuffed(Card{Rank: Five, Suit: Hearts}) {
+		t.Fatalf("expected only Spades to be debuffed")
+	}
+	if got := g.currentBoss.Describe(); got != "Double the chips needed to win, Halves your money, Hand size reduced by 2, Spades are debuffed" {
+		t.Fatalf("unexpected generated description %q", got)
+	}
+}
+
+// TestParseBossesYAML verifies composite and legacy boss definitions load.
+func TestParseBossesYAML(t *testing.T) {
+	data := []byte(`
+bosses:
+  - name: "Skull King"
+    effect: "DoubleChips"
+  - name: "The Tyrant"
+    description: "Spades score zero, -1 hand size"
+    effects:
+      - effect: "DebuffSuit"
+        suit: "Spades"
+      - effect: "ChangeHandSize"
+        magnitude: -1
+`)
+	bosses, err := parseBossesYAML(data)
+	if err != nil {
+		t.Fatalf("parseBossesYAML returned error: %v", err)
+	}
+	if len(bosses) != 2 {
+		t.Fatalf("expected 2 bosses, got %d", len(bosses))
+	}
+	if len(bosses[0].Effects) != 1 || bosses[0].Effects[0].Effect != DoubleChips {
+		t.Fatalf("expected legacy effect to become an effect list, got %#v", bosses[0].Effects)
+	}
+	if len(bosses[1].Effects) != 2 || bosses[1].Effects[0].Suit != "Spades" || bosses[1].Effects[1].Magnitude != -1 {
+		t.Fatalf("unexpected composite effects %#v", bosses[1].Effects)
+	}
+
+	invalid := [][]byte{
+		[]byte("bosses:\n  - name: \"Bad\"\n    effects:\n      - effect: \"Explode\"\n"),
+		[]byte("bosses:\n  - name: \"Bad\"\n    effects:\n      - effect: \"DebuffSuit\"\n        suit: \"Stars\"\n"),
+		[]byte("bosses:\n  - name: \"Bad\"\n    effects:\n      - effect: \"ChangeHandSize\"\n"),
+	}
+	for _, data := range invalid {
+		if _, err := parseBossesYAML(data); err == nil {
+			t.Errorf("expected error for %s", data)
+		}
+	}
+}
+
+// newBossTestGame returns a Boss Blind game against a boss with the given effects.
+func newBossTestGame(effects ...BossEffectConfig) (*Game, *testEventHandler) {
+	handler := &testEventHandler{}
+	g := &Game{
+		currentBlind: BossBlind,
+		currentBoss:  Boss{Name: "Test Boss", Effects: effects},
+		deck:         NewDeck(),
+		deckIndex:    7,
+		playerCards: []Card{
+			{Rank: Ten, Suit: Hearts},
+			{Rank: Ten, Suit: Clubs},
+			{Rank: King, Suit: Diamonds},
+			{Rank: Four, Suit: Spades},
+			{Rank: Five, Suit: Hearts},
+			{Rank: Six, Suit: Clubs},
+			{Rank: Seven, Suit: Diamonds},
+		},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+	g.applyDebuffs()
+	return g, handler
+}
+
+// lastHandPlayed returns the most recent HandPlayedEvent.
+func lastHandPlayed(t *testing.T, handler *testEventHandler) HandPlayedEvent {
+	t.Helper()
+	for i := len(handler.events) - 1; i >= 0; i-- {
+		if e, ok := handler.events[i].(HandPlayedEvent); ok {
+			return e
+		}
+	}
+	t.Fatalf("no HandPlayedEvent emitted")
+	return HandPlayedEvent{}
+}
+
+// TestBossHookDiscardsHeldCards verifies The Hook discards random held cards after a hand.
+func TestBossHookDiscardsHeldCards(t *testing.T) {
+	SetSeed(1)
+	g, _ := newBossTestGame(BossEffectConfig{Effect: DiscardHeldCards, Magnitude: 2})
+
+	g.handlePlayAction([]string{"1"})
+
+	if g.deckIndex != 10 {
+		t.Fatalf("expected 1 played + 2 discarded cards to be replaced, deck index %d", g.deckIndex)
+	}
+	if len(g.playerCards) != 7 {
+		t.Fatalf("expected hand to be refilled to 7, got %d", len(g.playerCards))
+	}
+}
+
+// TestBossWallMultipliesTarget verifies The Wall raises the target.
+func TestBossWallMultipliesTarget(t *testing.T) {
+	g, _ := newBossTestGame(BossEffectConfig{Effect: MultiplyTarget, Magnitude: 2})
+	g.currentTarget = 600
+	g.applyBossEffect()
+	if g.currentTarget != 1200 {
+		t.Fatalf("expected target 1200, got %d", g.currentTarget)
+	}
+}
+
+// TestBossNeedleAndWaterLimitResources verifies The Needle and The Water limits.
+func TestBossNeedleAndWaterLimitResources(t *testing.T) {
+	g, _ := newBossTestGame(BossEffectConfig{Effect: SetHands, Magnitude: 1}, BossEffectConfig{Effect: SetDiscards, Magnitude: 0})
+	if g.maxHands() != 1 {
+		t.Fatalf("expected 1 hand, got %d", g.maxHands())
+	}
+	if g.maxDiscards() != 0 {
+		t.Fatalf("expected 0 discards, got %d", g.maxDiscards())
+	}
+
+	g.currentBlind = BigBlind
+	if g.maxHands() != MaxHands || g.maxDiscards() != MaxDiscards {
+		t.Fatalf("expected boss limits to apply only during the Boss Blind")
+	}
+}
+
+// TestBossSuitAndFaceDebuffs verifies suit and face card bosses debuff cards in hand.
+func TestBossSuitAndFaceDebuffs(t *testing.T) {
+	for _, suit := range []string{"Clubs", "Spades", "Diamonds", "Hearts"} {
+		g, _ := newBossTestGame(BossEffectConfig{Effect: DebuffSuit, Suit: suit})
+		parsed, _ := ParseSuit(suit)
+		for _, c := range g.playerCards {
+			if c.Debuffed != (c.Suit == parsed) {
+				t.Errorf("%s debuff: card %s debuffed = %t", suit, c, c.Debuffed)
+			}
+		}
+	}
+
+	g, _ := newBossTestGame(BossEffectConfig{Effect: DebuffFaceCards})
+	for _, c := range g.playerCards {
+		if c.Debuffed != (c.Rank == King) {
+			t.Errorf("face card debuff: card %s debuffed = %t", c, c.Debuffed)
+		}
+	}
+
+	g.currentBlind = BigBlind
+	g.applyDebuffs()
+	for _, c := range g.playerCards {
+		if c.Debuffed {
+			t.Errorf("expected no debuffs outside the Boss Blind, %s is debuffed", c)
+		}
+	}
+}
+
+// TestBossPsychicRequiresFiveCards verifies The Psychic scores nothing for short hands.
+func TestBossPsychicRequiresFiveCards(t *testing.T) {
+	g, handler := newBossTestGame(BossEffectConfig{Effect: RequireCardCount, Magnitude: 5})
+
+	g.handlePlayAction([]string{"1", "2"})
+	if e := lastHandPlayed(t, handler); e.NotAllowed == "" || e.FinalScore != 0 {
+		t.Fatalf("expected a refused hand, got %+v", e)
+	}
+	if g.totalScore != 0 || g.handsPlayed != 1 {
+		t.Fatalf("expected no score and a used hand, got score %d hands %d", g.totalScore, g.handsPlayed)
+	}
+
+	g.handlePlayAction([]string{"1", "2", "3", "4", "5"})
+	if e := lastHandPlayed(t, handler); e.NotAllowed != "" || g.totalScore == 0 {
+		t.Fatalf("expected 5 card hand to score, got %+v", e)
+	}
+}
+
+// TestBossEyeAndMouthRestrictHandTypes verifies The Eye and The Mouth hand type rules.
+func TestBossEyeAndMouthRestrictHandTypes(t *testing.T) {
+	g, _ := newBossTestGame(BossEffectConfig{Effect: NoRepeatHandTypes})
+	g.handTypesPlayed = []string{"Pair"}
+	if reason := g.bossDisallowsHand("Pair", 2); reason == "" {
+		t.Fatalf("expected The Eye to refuse a repeated Pair")
+	}
+	if reason := g.bossDisallowsHand("High Card", 1); reason != "" {
+		t.Fatalf("expected The Eye to allow a new hand type, got %q", reason)
+	}
+
+	g, _ = newBossTestGame(BossEffectConfig{Effect: SingleHandType})
+	g.handlePlayAction([]string{"1"})
+	if g.handTypesPlayed[0] != "High Card" {
+		t.Fatalf("expected first hand type to be recorded, got %v", g.handTypesPlayed)
+	}
+	if reason := g.bossDisallowsHand("Pair", 2); reason == "" {
+		t.Fatalf("expected The Mouth to refuse a different hand type")
+	}
+	if reason := g.bossDisallowsHand("High Card", 1); reason != "" {
+		t.Fatalf("expected The Mouth to allow the same hand type, got %q", reason)
+	}
+}
+
+// TestBossFlintHalvesBase verifies The Flint halves base chips and mult.
+func TestBossFlintHalvesBase(t *testing.T) {
+	g, _ := newBossTestGame(BossEffectConfig{Effect: HalveBaseScore})
+	chips, mult := g.applyBossBaseModifiers(10, 2)
+	if chips != 5 || mult != 1 {
+		t.Fatalf("expected 5 chips and 1 mult, got %d and %d", chips, mult)
+	}
+	chips, mult = g.applyBossBaseModifiers(5, 1)
+	if chips != 3 || mult != 1 {
+		t.Fatalf("expected halving to round up, got %d and %d", chips, mult)
+	}
+}
+
+// TestGetBossForAnteRespectsMinAnte verifies bosses only appear from their minimum ante.
+func TestGetBossForAnteRespectsMinAnte(t *testing.T) {
+	setDefaultBosses()
+	for seed := int64(0); seed < 50; seed++ {
+		SetSeed(seed)
+		if boss := GetBossForAnte(1); boss.MinAnte > 1 {
+			t.Fatalf("seed %d: %s appeared before ante %d", seed, boss.Name, boss.MinAnte)
+		}
+		if GetBossForAnte(3).Name != GetBossForAnte(3).Name {
+			t.Fatalf("seed %d: boss choice should be stable for a run", seed)
+		}
+	}
+}
+
+// TestShowdownBossesAreFinal verifies the showdown bosses only appear on final antes.
+func TestShowdownBossesAreFinal(t *testing.T) {
+	setDefaultBosses()
+	names := make(map[string]bool)
+	for _, b := range finalBosses {
+		names[b.Name] = b.Final
+	}
+	for _, name := range []string{"Violet Vessel", "Crimson Heart", "Cerulean Bell", "Amber Acorn", "Verdant Leaf"} {
+		if !names[name] {
+			t.Errorf("expected %s to be a final boss", name)
+		}
+	}
+	for _, ante := range []int{8, 16, 24} {
+		if boss := GetBossForAnte(ante); !boss.Final {
+			t.Errorf("expected a showdown boss on ante %d, got %s", ante, boss.Name)
+		}
+	}
+}
+
+// TestCrimsonHeartDisablesJokerEachHand verifies one joker is disabled for every hand.
+func TestCrimsonHeartDisablesJokerEachHand(t *testing.T) {
+	SetSeed(1)
+	g, handler := newBossTestGame(BossEffectConfig{Effect: DisableRandomJoker})
+	g.jokers = []OwnedJoker{
+		{Joker: Joker{Name: "Mult A", Effects: []JokerEffectConfig{{Effect: AddMult, EffectMagnitude: 4, HandMatchingRule: None, CardMatchingRule: CardNone}}}},
+		{Joker: Joker{Name: "Mult B", Effects: []JokerEffectConfig{{Effect: AddMult, EffectMagnitude: 4, HandMatchingRule: None, CardMatchingRule: CardNone}}}},
+	}
+
+	countDisabled := func() int {
+		n := 0
+		for _, j := range g.jokers {
+			if j.Disabled {
+				n++
+			}
+		}
+		return n
+	}
+
+	g.applyBossJokerEffects()
+	if countDisabled() != 1 {
+		t.Fatalf("expected 1 disabled joker when play begins, got %d", countDisabled())
+	}
+
+	g.handlePlayAction([]string{"1"})
+	if e := lastHandPlayed(t, handler); e.JokerMult != 4 {
+		t.Fatalf("expected only the enabled joker to add mult, got %d", e.JokerMult)
+	}
+	if countDisabled() != 1 {
+		t.Fatalf("expected a joker to be disabled for the next hand, got %d", countDisabled())
+	}
+
+	g.clearBossJokerEffects()
+	if countDisabled() != 0 {
+		t.Fatalf("expected jokers to be re-enabled after the blind")
+	}
+}
+
+// TestCeruleanBellForcesCardSelection verifies the forced card is always played.
+func TestCeruleanBellForcesCardSelection(t *testing.T) {
+	SetSeed(1)
+	g, handler := newBossTestGame(BossEffectConfig{Effect: ForceCardSelection})
+	g.applyForcedSelection()
+
+	forced := -1
+	for i, c := range g.playerCards {
+		if c.Forced {
+			if forced != -1 {
+				t.Fatalf("expected exactly one forced card")
+			}
+			forced = i
+		}
+	}
+	if forced == -1 {
+		t.Fatalf("expected a forced card")
+	}
+
+	var others []string
+	for i := range g.playerCards {
+		if i != forced && len(others) < 5 {
+			others = append(others, strconv.Itoa(i+1))
+		}
+	}
+	g.handlePlayAction(others)
+	if g.handsPlayed != 0 {
+		t.Fatalf("expected 5 other cards plus the forced card to be rejected")
+	}
+	if _, ok := handler.events[len(handler.events)-1].(InvalidActionEvent); !ok {
+		t.Fatalf("expected an InvalidActionEvent, got %T", handler.events[len(handler.events)-1])
+	}
+
+	forcedCard := g.playerCards[forced]
+	g.handlePlayAction(others[:1])
+	played := lastHandPlayed(t, handler).SelectedCards
+	if len(played) != 2 || played[1] != forcedCard {
+		t.Fatalf("expected the forced card %s to be added to the hand, got %v", forcedCard, played)
+	}
+}
+
+// TestAmberAcornFlipsJokers verifies jokers are face down during the blind.
+func TestAmberAcornFlipsJokers(t *testing.T) {
+	SetSeed(1)
+	g, _ := newBossTestGame(BossEffectConfig{Effect: FlipJokers})
+	g.jokers = []OwnedJoker{{Joker: Joker{Name: "A", Description: "first"}}, {Joker: Joker{Name: "B", Description: "second"}}, {Joker: Joker{Name: "C", Description: "third"}}}
+
+	g.applyBossJokerEffects()
+	for _, j := range g.jokers {
+		if !j.FaceDown || !j.Active() {
+			t.Fatalf("expected %s to be face down but still active", j.Name)
+		}
+		if name, desc := j.Face(); name == j.Name || desc == j.Description {
+			t.Fatalf("expected face-down joker to hide its name, got %s: %s", name, desc)
+		}
+	}
+
+	g.clearBossJokerEffects()
+	if name, _ := g.jokers[0].Face(); name != g.jokers[0].Name {
+		t.Fatalf("expected jokers to be face up after the blind")
+	}
+}
+
+// TestVerdantLeafDisabledBySellingJoker verifies all cards are debuffed until a joker is sold.
+func TestVerdantLeafDisabledBySellingJoker(t *testing.T) {
+	g, _ := newBossTestGame(BossEffectConfig{Effect: DebuffAllCards})
+	g.jokers = []OwnedJoker{{Joker: Joker{Name: "Spare", Price: 4}, PurchasePrice: 4}}
+	for _, c := range g.playerCards {
+		if !c.Debuffed {
+			t.Fatalf("expected %s to be debuffed", c)
+		}
+	}
+
+	if !g.handleSellJokerAction([]string{"1"}) {
+		t.Fatalf("expected the joker to be sold")
+	}
+	g.disableBossOnJokerSold()
+	for _, c := range g.playerCards {
+		if c.Debuffed {
+			t.Fatalf("expected %s to no longer be debuffed", c)
+		}
+	}
+	if g.bossEffects() != nil {
+		t.Fatalf("expected the boss to be disabled")
+	}
+}
@@ -3,8 +3,10 @@ package game
 import (
 	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -17,12 +19,174 @@ const (
 	DoubleChips BossEffect = "DoubleChips"
 	// HalveMoney halves the player's money when the blind starts
 	HalveMoney BossEffect = "HalveMoney"
+	// DebuffSuit debuffs cards of the given suit
+	DebuffSuit BossEffect = "DebuffSuit"
+	// ChangeHandSize adds magnitude (which may be negative) to the hand size
+	ChangeHandSize BossEffect = "ChangeHandSize"
+	// MultiplyTarget multiplies the chip target by magnitude
+	MultiplyTarget BossEffect = "MultiplyTarget"
+	// DiscardHeldCards discards magnitude random held cards after every hand played
+	DiscardHeldCards BossEffect = "DiscardHeldCards"
+	// SetHands limits the blind to magnitude hands
+	SetHands BossEffect = "SetHands"
+	// SetDiscards limits the blind to magnitude discards
+	SetDiscards BossEffect = "SetDiscards"
+	// DebuffFaceCards debuffs face cards
+	DebuffFaceCards BossEffect = "DebuffFaceCards"
+	// RequireCardCount only scores hands of exactly magnitude cards
+	RequireCardCount BossEffect = "RequireCardCount"
+	// NoRepeatHandTypes only scores each hand type once per blind
+	NoRepeatHandTypes BossEffect = "NoRepeatHandTypes"
+	// SingleHandType only scores the first hand type played this blind
+	SingleHandType BossEffect = "SingleHandType"
+	// HalveBaseScore halves the base chips and mult of every hand
+	HalveBaseScore BossEffect = "HalveBaseScore"
+	// DisableRandomJoker disables a random joker for every hand
+	DisableRandomJoker BossEffect = "DisableRandomJoker"
+	// ForceCardSelection forces one card in hand to be selected every hand
+	ForceCardSelection BossEffect = "ForceCardSelection"
+	// FlipJokers flips jokers face down and shuffles them when play begins
+	FlipJokers BossEffect = "FlipJokers"
+	// DebuffAllCards debuffs every card until a joker is sold, which
+	// disables the boss
+	DebuffAllCards BossEffect = "DebuffAllCards"
 )
 
+// BossEffectConfig is a single effect component of a boss
+type BossEffectConfig struct {
+	Effect    BossEffect `yaml:"effect"`
+	Magnitude int        `yaml:"magnitude"`
+	Suit      string     `yaml:"suit"`
+}
+
+// Describe returns a human-readable description of the effect
+func (e BossEffectConfig) Describe() string {
+	switch e.Effect {
+	case DoubleChips:
+		return "Double the chips needed to win"
+	case HalveMoney:
+		return "Halves your money"
+	case DebuffSuit:
+		return fmt.Sprintf("%s are debuffed", e.Suit)
+	case ChangeHandSize:
+		if e.Magnitude < 0 {
+			return fmt.Sprintf("Hand size reduced by %d", -e.Magnitude)
+		}
+		return fmt.Sprintf("Hand size increased by %d", e.Magnitude)
+	case MultiplyTarget:
+		return fmt.Sprintf("%dx the chips needed to win", e.Magnitude)
+	case DiscardHeldCards:
+		return fmt.Sprintf("Discards %d random held cards after every hand", e.Magnitude)
+	case SetHands:
+		return fmt.Sprintf("Play only %d hand(s)", e.Magnitude)
+	case SetDiscards:
+		if e.Magnitude == 0 {
+			return "Start with 0 discards"
+		}
+		return fmt.Sprintf("Only %d discard(s)", e.Magnitude)
+	case DebuffFaceCards:
+		return "Face cards are debuffed"
+	case RequireCardCount:
+		return fmt.Sprintf("Must play %d cards", e.Magnitude)
+	case NoRepeatHandTypes:
+		return "No repeat hand types this round"
+	case SingleHandType:
+		return "Play only 1 hand type this round"
+	case HalveBaseScore:
+		return "Base chips and mult are halved"
+	case DisableRandomJoker:
+		return "One random Joker disabled every hand"
+	case ForceCardSelection:
+		return "Forces 1 card to always be selected"
+	case FlipJokers:
+		return "Flips and shuffles all Jokers"
+	case DebuffAllCards:
+		return "All cards debuffed until 1 Joker sold"
+	default:
+		return string(e.Effect)
+	}
+}
+
+// validate checks the effect is known and has the parameters it needs
+func (e BossEffectConfig) validate() error {
+	switch e.Effect {
+	case DoubleChips, HalveMoney, DebuffFaceCards, NoRepeatHandTypes, SingleHandType, HalveBaseScore,
+		DisableRandomJoker, ForceCardSelection, FlipJokers, DebuffAllCards:
+		return nil
+	case DebuffSuit:
+		_, err := ParseSuit(e.Suit)
+		return err
+	case ChangeHandSize:
+		if e.Magnitude == 0 {
+			return fmt.Errorf("%s needs a non-zero magnitude", e.Effect)
+		}
+		return nil
+	case MultiplyTarget, DiscardHeldCards, SetHands:
+		if e.Magnitude < 1 {
+			return fmt.Errorf("%s needs a magnitude of at least 1", e.Effect)
+		}
+		return nil
+	case SetDiscards:
+		if e.Magnitude < 0 {
+			return fmt.Errorf("%s needs a magnitude of at least 0", e.Effect)
+		}
+		return nil
+	case RequireCardCount:
+		if e.Magnitude < 1 || e.Magnitude > 5 {
+			return fmt.Errorf("%s needs a magnitude between 1 and 5", e.Effect)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown boss effect %q", e.Effect)
+	}
+}
+
+// Boss is a Boss Blind with a list of composable effects
 type Boss struct {
-	Name   string     `yaml:"name"`
+	Name        string             `yaml:"name"`
+	Description string             `yaml:"description"`
+	Effects     []BossEffectConfig `yaml:"effects"`
+	Final       bool               `yaml:"final"`
+	// MinAnte is the earliest ante the boss can appear on
+	MinAnte int `yaml:"min_ante"`
+	// Legacy single-effect field for backward compatibility
 	Effect BossEffect `yaml:"effect"`
-	Final  bool       `yaml:"final"`
+}
+
+// Describe returns the boss description, built from its effects if none is set
+func (b Boss) Describe() string {
+	if b.Description != "" {
+		return b.Description
+	}
+	var parts []string
+	for _, eff := range b.Effects {
+		parts = append(parts, eff.Describe())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Label returns the boss name with its description for display
+func (b Boss) Label() string {
+	if b.Name == "" {
+		return ""
+	}
+	if desc := b.Describe(); desc != "" {
+		return fmt.Sprintf("%s - %s", b.Name, desc)
+	}
+	return b.Name
+}
+
+// ScaleTarget applies the boss's target effects to a blind target
+func (b Boss) ScaleTarget(target Score) Score {
+	for _, eff := range b.Effects {
+		switch eff.Effect {
+		case DoubleChips:
+			target = target.Mul(2)
+		case MultiplyTarget:
+			target = target.Mul(Score(eff.Magnitude))
+		}
+	}
+	return target
 }
 
 type BossesYAML struct {
@@ -55,15 +219,12 @@ func loadBossesFromYAML() error {
 		return err
 	}
 
-	var bossesYAML BossesYAML
-	if err := yaml.Unmarshal(data, &bossesYAML); err != nil {
+	bosses, err := parseBossesYAML(data)
+	if err != nil {
 		return err
 	}
-	if len(bossesYAML.Bosses) == 0 {
-		return fmt.Errorf("bosses.yaml contains no bosses")
-	}
 
-	for _, b := range bossesYAML.Bosses {
+	for _, b := range bosses {
 		if b.Final {
 			finalBosses = append(finalBosses, b)
 		} else {
@@ -74,23 +235,81 @@ func loadBossesFromYAML() error {
 	return nil
 }
 
+// parseBossesYAML decodes and validates boss definitions
+func parseBossesYAML(data []byte) ([]Boss, error) {
+	var bossesYAML BossesYAML
+	if err := yaml.Unmarshal(data, &bossesYAML); err != nil {
+		return nil, err
+	}
+	if len(bossesYAML.Bosses) == 0 {
+		return nil, fmt.Errorf("bosses.yaml contains no bosses")
+	}
+
+	for i, b := range bossesYAML.Bosses {
+		if b.Effect != "" {
+			b.Effects = append([]BossEffectConfig{{Effect: b.Effect}}, b.Effects...)
+			b.Effect = ""
+		}
+		for _, eff := range b.Effects {
+			if err := eff.validate(); err != nil {
+				return nil, fmt.Errorf("boss %q: %v", b.Name, err)
+			}
+		}
+		bossesYAML.Bosses[i] = b
+	}
+
+	return bossesYAML.Bosses, nil
+}
+
 func setDefaultBosses() {
 	regularBosses = []Boss{
-		{Name: "Skull King", Effect: DoubleChips},
+		{Name: "The Hook", Description: "Discards 2 random held cards after every hand", Effects: []BossEffectConfig{{Effect: DiscardHeldCards, Magnitude: 2}}},
+		{Name: "The Wall", Description: "Extra large blind", Effects: []BossEffectConfig{{Effect: MultiplyTarget, Magnitude: 2}}, MinAnte: 2},
+		{Name: "The Needle", Description: "Play only 1 hand", Effects: []BossEffectConfig{{Effect: SetHands, Magnitude: 1}}, MinAnte: 2},
+		{Name: "The Water", Description: "Start with 0 discards", Effects: []BossEffectConfig{{Effect: SetDiscards, Magnitude: 0}}, MinAnte: 2},
+		{Name: "The Club", Description: "All Club cards are debuffed", Effects: []BossEffectConfig{{Effect: DebuffSuit, Suit: "Clubs"}}},
+		{Name: "The Goad", Description: "All Spade cards are debuffed", Effects: []BossEffectConfig{{Effect: DebuffSuit, Suit: "Spades"}}},
+		{Name: "The Window", Description: "All Diamond cards are debuffed", Effects: []BossEffectConfig{{Effect: DebuffSuit, Suit: "Diamonds"}}},
+		{Name: "The Head", Description: "All Heart cards are debuffed", Effects: []BossEffectConfig{{Effect: DebuffSuit, Suit: "Hearts"}}},
+		{Name: "The Plant", Description: "All face cards are debuffed", Effects: []BossEffectConfig{{Effect: DebuffFaceCards}}, MinAnte: 4},
+		{Name: "The Psychic", Description: "Must play 5 cards", Effects: []BossEffectConfig{{Effect: RequireCardCount, Magnitude: 5}}},
+		{Name: "The Eye", Description: "No repeat hand types this round", Effects: []BossEffectConfig{{Effect: NoRepeatHandTypes}}, MinAnte: 3},
+		{Name: "The Mouth", Description: "Play only 1 hand type this round", Effects: []BossEffectConfig{{Effect: SingleHandType}}, MinAnte: 2},
+		{Name: "The Flint", Description: "Base chips and mult are halved", Effects: []BossEffectConfig{{Effect: HalveBaseScore}}, MinAnte: 2},
+		{Name: "The Manacle", Description: "Hand size reduced by 1", Effects: []BossEffectConfig{{Effect: ChangeHandSize, Magnitude: -1}}},
+		{Name: "Skull King", Description: "Double the chips needed to win", Effects: []BossEffectConfig{{Effect: DoubleChips}}},
+		{Name: "The Open Palm", Description: "Hand size increased by 1", Effects: []BossEffectConfig{{Effect: ChangeHandSize, Magnitude: 1}}},
 	}
 	finalBosses = []Boss{
-		{Name: "The Void", Effect: HalveMoney, Final: true},
+		{Name: "Violet Vessel", Description: "Very large blind", Effects: []BossEffectConfig{{Effect: MultiplyTarget, Magnitude: 3}}, Final: true},
+		{Name: "Crimson Heart", Description: "One random Joker disabled every hand", Effects: []BossEffectConfig{{Effect: DisableRandomJoker}}, Final: true},
+		{Name: "Cerulean Bell", Description: "Forces 1 card to always be selected", Effects: []BossEffectConfig{{Effect: ForceCardSelection}}, Final: true},
+		{Name: "Amber Acorn", Description: "Flips and shuffles all Jokers", Effects: []BossEffectConfig{{Effect: FlipJokers}}, Final: true},
+		{Name: "Verdant Leaf", Description: "All cards debuffed until 1 Joker sold", Effects: []BossEffectConfig{{Effect: DebuffAllCards}}, Final: true},
+		{Name: "The Void", Description: "Halves your money", Effects: []BossEffectConfig{{Effect: HalveMoney}}, Final: true},
 	}
 }
 
+// GetBossForAnte returns the boss for an ante. Every 8th ante is a showdown
+// against a final boss; other antes pick a regular boss allowed at that ante.
+// Picks are seeded by the run seed so the same run always meets the same
+// bosses.
 func GetBossForAnte(ante int) Boss {
 	if ante%8 == 0 {
 		if len(finalBosses) > 0 {
-			return finalBosses[(ante/8-1)%len(finalBosses)]
+			r := rand.New(rand.NewSource(GetSeed() + int64(ante)))
+			return finalBosses[r.Intn(len(finalBosses))]
 		}
 	} else {
-		if len(regularBosses) > 0 {
-			return regularBosses[(ante-1)%len(regularBosses)]
+		var eligible []Boss
+		for _, b := range regularBosses {
+			if b.MinAnte <= ante {
+				eligible = append(eligible, b)
+			}
+		}
+		if len(eligible) > 0 {
+			r := rand.New(rand.NewSource(GetSeed() + int64(ante)))
+			return eligible[r.Intn(len(eligible))]
 		}
 	}
 
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// split separates a card rule's name from its parameter, e.g. "IsSuit" and
+// "Hearts" for "IsSuit:Hearts"
+func (r CardMatchingRule) split() (CardMatchingRule, string) {
+	name, param, _ := strings.Cut(string(r), ":")
+	return CardMatchingRule(strings.TrimSpace(name)), strings.TrimSpace(param)
+}
+
+// validateCardRule checks a card rule is known and has the parameter it
+// needs
+func validateCardRule(rule CardMatchingRule) error {
+	name, param := rule.split()
+	switch name {
+	case CardNone, CardIsAce, CardIsSpade, CardIsFace, CardIsEven, CardIsOdd, CardIsNumbered:
+		if param != "" {
+			return fmt.Errorf("%s takes no parameter", name)
+		}
+	case CardIsSuit:
+		if _, err := ParseSuit(param); err != nil {
+			return fmt.Errorf("%v (expected e.g. IsSuit:Hearts)", err)
+		}
+	case CardRankIn:
+		if param == "" {
+			return fmt.Errorf("RankIn needs ranks (expected e.g. RankIn:2,3,4,5)")
+		}
+		for _, rank := range strings.Split(param, ",") {
+			if _, err := ParseRank(strings.TrimSpace(rank)); err != nil {
+				return fmt.Errorf("%v (expected A, 2-10, J, Q or K)", err)
+			}
+		}
+	case CardHasEnhancement:
+		if param != "" && !containsFold(enhancements, param) {
+			return fmt.Errorf("unknown enhancement %q (expected %s)", param, strings.Join(enhancements, ", "))
+		}
+	case CardHasSeal:
+		if param != "" {
+			if _, err := ParseSeal(param); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown card rule %q", rule)
+	}
+	return nil
+}
+
+// CardModifiers are the ways jokers change how card rules read cards
+type CardModifiers struct {
+	// AllFace makes every card count as a face card (Pareidolia)
+	AllFace bool
+	// SuitGroups are sets of suits that count as each other (Smeared Joker)
+	SuitGroups [][]Suit
+}
+
+// cardModifiers collects the card modifiers of the active jokers
+func cardModifiers(jokers []OwnedJoker) CardModifiers {
+	var m CardModifiers
+	for _, joker := range jokers {
+		if !joker.Active() {
+			continue
+		}
+		for _, eff := range joker.Effects {
+			switch eff.Effect {
+			case CountAllAsFace:
+				m.AllFace = true
+			case MergeSuits:
+				var group []Suit
+				for _, name := range eff.Suits {
+					if suit, err := ParseSuit(name); err == nil {
+						group = append(group, suit)
+					}
+				}
+				m.SuitGroups = append(m.SuitGroups, group)
+			}
+		}
+	}
+	return m
+}
+
+// isFace reports whether the card counts as a face card
+func (m CardModifiers) isFace(card Card) bool {
+	return m.AllFace || isFaceCard(card)
+}
+
+// hasSuit reports whether the card counts as the suit, either by being it
+// or by sharing a suit group with it
+func (m CardModifiers) hasSuit(card Card, suit Suit) bool {
+	if card.Suit == suit {
+		return true
+	}
+	for _, group := range m.SuitGroups {
+		if containsSuit(group, card.Suit) && containsSuit(group, suit) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnySuit reports whether the card counts as any of the named suits
+func (m CardModifiers) hasAnySuit(card Card, names []string) bool {
+	for _, name := range names {
+		if suit, err := ParseSuit(name); err == nil && m.hasSuit(card, suit) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsSuit reports whether suits holds s
+func containsSuit(suits []Suit, s Suit) bool {
+	for _, suit := range suits {
+		if suit == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParameterizedCardRules verifies card rules for suits, rank sets,
+// parity, numbered cards, enhancements and seals.
+func TestParameterizedCardRules(t *testing.T) {
+	cases := []struct {
+		rule CardMatchingRule
+		card Card
+		want bool
+	}{
+		{"IsSuit:Hearts", Card{Rank: Two, Suit: Hearts}, true},
+		{"IsSuit:hearts", Card{Rank: Two, Suit: Diamonds}, false},
+		{"RankIn:10,4", Card{Rank: Four, Suit: Clubs}, true},
+		{"RankIn:10, 4", Card{Rank: Ten, Suit: Clubs}, true},
+		{"RankIn:A,K", Card{Rank: Queen, Suit: Clubs}, false},
+		{CardIsEven, Card{Rank: Eight, Suit: Clubs}, true},
+		{CardIsOdd, Card{Rank: Ace, Suit: Clubs}, true},
+		{CardIsOdd, Card{Rank: Jack, Suit: Clubs}, false},
+		{CardIsNumbered, Card{Rank: Ten, Suit: Clubs}, true},
+		{CardIsNumbered, Card{Rank: Ace, Suit: Clubs}, false},
+		{CardHasEnhancement, Card{Rank: Two, Suit: Clubs, Gold: true}, true},
+		{"HasEnhancement:Gold", Card{Rank: Two, Suit: Clubs}, false},
+		{CardHasSeal, Card{Rank: Two, Suit: Clubs, Seal: BlueSeal}, true},
+		{"HasSeal:Red", Card{Rank: Two, Suit: Clubs, Seal: BlueSeal}, false},
+		{"HasSeal:red", Card{Rank: Two, Suit: Clubs, Seal: RedSeal}, true},
+		{"IsSuit:Hearts", Card{Rank: Two, Suit: Hearts, Debuffed: true}, false},
+	}
+	for _, c := range cases {
+		if got := cardMatchesRule(c.card, c.rule, CardModifiers{}); got != c.want {
+			t.Errorf("%s on %s: got %v, want %v", c.rule, c.card, got, c.want)
+		}
+	}
+
+	sealed := CardCondition{Seals: []string{"Red", "Gold"}, Numbered: boolPtr(true)}
+	if !sealed.matches(Card{Rank: Five, Suit: Clubs, Seal: GoldSeal}, CardModifiers{}) {
+		t.Error("expected a Gold Seal 5 to match a numbered, Red or Gold Seal condition")
+	}
+	if sealed.matches(Card{Rank: King, Suit: Clubs, Seal: RedSeal}, CardModifiers{}) {
+		t.Error("expected a King not to count as numbered")
+	}
+}
+
+// TestCardModifiers verifies Pareidolia makes every card a face card and
+// Smeared Joker makes suits count as each other, for card rules, card
+// conditions and bosses alike.
+func TestCardModifiers(t *testing.T) {
+	pareidolia := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Pareidolia", Effect: CountAllAsFace}))
+	smeared := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Smeared Joker", Effects: []JokerEffectConfig{
+		{Effect: MergeSuits, Suits: []string{"Hearts", "Diamonds"}},
+		{Effect: MergeSuits, Suits: []string{"Spades", "Clubs"}},
+	}}))
+	faces := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Faces", Effect: AddMult, EffectMagnitude: 2, CardMatchingRule: CardIsFace}))
+	hearts := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Hearts", Effect: AddChips, EffectMagnitude: 5, CardCondition: &CardCondition{Suits: []string{"Hearts"}}}))
+	spades := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Spades", Effect: AddChips, EffectMagnitude: 7, CardMatchingRule: CardIsSpade}))
+	state := ConditionState{HandType: "High Card"}
+	played := []Card{{Rank: Two, Suit: Diamonds}, {Rank: Three, Suit: Clubs}, {Rank: King, Suit: Hearts}}
+
+	if got := stepNames(JokerScoringSteps([]OwnedJoker{faces}, state, played, nil)); got != "Faces:AddMult" {
+		t.Errorf("without Pareidolia: steps = %q, want only the King", got)
+	}
+	if got := stepNames(JokerScoringSteps([]OwnedJoker{pareidolia, faces}, state, played, nil)); got != "Faces:AddMult Faces:AddMult Faces:AddMult" {
+		t.Errorf("with Pareidolia: steps = %q, want every card", got)
+	}
+	if got := stepNames(JokerScoringSteps([]OwnedJoker{hearts, spades, smeared}, state, played, nil)); got != "Hearts:AddChips Spades:AddChips Hearts:AddChips" {
+		t.Errorf("with Smeared Joker: steps = %q, want the Diamond and Heart as Hearts and the Club as a Spade", got)
+	}
+
+	debuffed := pareidolia
+	debuffed.Debuffed = true
+	if m := cardModifiers([]OwnedJoker{debuffed}); m.AllFace {
+		t.Error("expected a debuffed Pareidolia not to change face cards")
+	}
+	blueprint := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Blueprint", Effect: CopyJokerRight}))
+	if got := stepNames(JokerScoringSteps([]OwnedJoker{blueprint, pareidolia}, state, played, nil)); got != "" {
+		t.Errorf("expected Blueprint to have nothing to copy from Pareidolia, got %q", got)
+	}
+
+	g, _ := newBossTestGame(BossEffectConfig{Effect: DebuffFaceCards})
+	g.jokers = []OwnedJoker{pareidolia}
+	g.applyDebuffs()
+	for _, c := range g.playerCards {
+		if !c.Debuffed {
+			t.Errorf("expected The Plant to debuff %s with Pareidolia", c)
+		}
+	}
+	g, _ = newBossTestGame(BossEffectConfig{Effect: DebuffSuit, Suit: "Hearts"})
+	g.jokers = []OwnedJoker{smeared}
+	g.applyDebuffs()
+	for _, c := range g.playerCards {
+		if red := c.Suit == Hearts || c.Suit == Diamonds; c.Debuffed != red {
+			t.Errorf("expected The Head to debuff only red cards with Smeared Joker, %s debuffed = %t", c, c.Debuffed)
+		}
+	}
+}
+
+// TestValidateCardRules verifies bad card rules and card modifiers are
+// reported with the joker's name and the field at fault.
+func TestValidateCardRules(t *testing.T) {
+	cases := []struct {
+		config JokerConfig
+		want   string
+	}{
+		{JokerConfig{Name: "Hearts", Effect: AddMult, CardMatchingRule: "IsHeart"}, `card_matching_rule: unknown card rule "IsHeart"`},
+		{JokerConfig{Name: "Hearts", Effect: AddMult, CardMatchingRule: "IsSuit:Hart"}, `card_matching_rule: unknown suit "Hart"`},
+		{JokerConfig{Name: "Walkie Talkie", Effect: AddMult, CardMatchingRule: "RankIn"}, "RankIn needs ranks"},
+		{JokerConfig{Name: "Walkie Talkie", Effect: AddMult, CardMatchingRule: "RankIn:10,1"}, `unknown rank "1"`},
+		{JokerConfig{Name: "Aces", Effect: AddMult, CardMatchingRule: "IsAce:Spades"}, "IsAce takes no parameter"},
+		{JokerConfig{Name: "Golden Ticket", Effect: AddMoney, Trigger: TriggerCardScored, CardMatchingRule: "HasEnhancement:Steel"}, `unknown enhancement "Steel"`},
+		{JokerConfig{Name: "Seals", Effect: AddMult, CardMatchingRule: "HasSeal:Green"}, `unknown seal "Green"`},
+		{JokerConfig{Name: "Seals", Effect: AddMult, CardCondition: &CardCondition{Seals: []string{"Red", "Green"}}}, `card_condition.seals[1]: unknown seal "Green"`},
+		{JokerConfig{Name: "Runner", Effect: AddChips, Counter: &JokerCounter{Updates: []CounterUpdate{
+			{On: TriggerCardScored, Add: 1, CardMatchingRule: "IsSuit:Cups"},
+		}}}, `counter.updates[0].card_matching_rule: unknown suit "Cups"`},
+		{JokerConfig{Name: "Smeared Joker", Effect: MergeSuits, Suits: []string{"Hearts"}}, "needs at least two suits"},
+		{JokerConfig{Name: "Smeared Joker", Effect: MergeSuits, Suits: []string{"Hearts", "Stars"}}, `suits[1]: unknown suit "Stars"`},
+		{JokerConfig{Name: "Plus", Effect: AddMult, Suits: []string{"Hearts", "Diamonds"}}, "only MergeSuits takes suits"},
+		{JokerConfig{Name: "Pareidolia", Effect: CountAllAsFace, Trigger: TriggerHandPlayed}, "can't have a trigger"},
+	}
+	for _, c := range cases {
+		err := validateJokerConfig(c.config)
+		if err == nil || !strings.Contains(err.Error(), "joker "+c.config.Name) || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("validateJokerConfig(%s) = %v, want an error mentioning %q", c.config.Name, err, c.want)
+		}
+	}
+}
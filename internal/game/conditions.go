@@ -0,0 +1,291 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConditionState is the game state joker conditions are checked against
+type ConditionState struct {
+	// HandType is the hand being played, or empty outside of a hand
+	HandType string
+	// PlayedCards is how many cards were played in the hand
+	PlayedCards  int
+	Money        int
+	DiscardsUsed int
+	// HandsLeft counts the hand being played until it has been scored
+	HandsLeft int
+	// Cards is how the jokers change the way cards are read; it's filled
+	// in from the jokers whenever they fire
+	Cards CardModifiers
+}
+
+// conditionState captures the state joker conditions see right now
+func (g *Game) conditionState(handType string, played []Card) ConditionState {
+	return ConditionState{
+		HandType:     handType,
+		PlayedCards:  len(played),
+		Money:        g.money,
+		DiscardsUsed: g.discardsUsed,
+		HandsLeft:    g.maxHands() - g.handsPlayed,
+	}
+}
+
+// Bounds limits a number to a range; either end can be left open
+type Bounds struct {
+	Min *int `yaml:"min"`
+	Max *int `yaml:"max"`
+}
+
+// contains reports whether n is within the bounds
+func (b *Bounds) contains(n int) bool {
+	return (b.Min == nil || n >= *b.Min) && (b.Max == nil || n <= *b.Max)
+}
+
+// validate checks the bounds have an end and are in order
+func (b *Bounds) validate(path string) error {
+	if b.Min == nil && b.Max == nil {
+		return fmt.Errorf("%s: needs min or max", path)
+	}
+	if b.Min != nil && b.Max != nil && *b.Min > *b.Max {
+		return fmt.Errorf("%s: min %d is above max %d", path, *b.Min, *b.Max)
+	}
+	return nil
+}
+
+// Condition limits an effect or counter update to certain game states.
+// Every field that's set must hold; any_of holds when one of its conditions
+// does, and not holds when its condition doesn't.
+type Condition struct {
+	PlayedCards  *Bounds `yaml:"played_cards"`
+	Money        *Bounds `yaml:"money"`
+	DiscardsUsed *Bounds `yaml:"discards_used"`
+	HandsLeft    *Bounds `yaml:"hands_left"`
+	// FinalHand holds while the last hand of the round is being played
+	FinalHand bool        `yaml:"final_hand"`
+	AnyOf     []Condition `yaml:"any_of"`
+	Not       *Condition  `yaml:"not"`
+}
+
+// holds reports whether the game state satisfies the condition
+func (c *Condition) holds(s ConditionState) bool {
+	if c.PlayedCards != nil && !c.PlayedCards.contains(s.PlayedCards) {
+		return false
+	}
+	if c.Money != nil && !c.Money.contains(s.Money) {
+		return false
+	}
+	if c.DiscardsUsed != nil && !c.DiscardsUsed.contains(s.DiscardsUsed) {
+		return false
+	}
+	if c.HandsLeft != nil && !c.HandsLeft.contains(s.HandsLeft) {
+		return false
+	}
+	if c.FinalHand && (s.HandType == "" || s.HandsLeft != 1) {
+		return false
+	}
+	if len(c.AnyOf) > 0 {
+		any := false
+		for i := range c.AnyOf {
+			if c.AnyOf[i].holds(s) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	return c.Not == nil || !c.Not.holds(s)
+}
+
+// validate checks the condition and everything nested in it, naming the
+// offending field in errors
+func (c *Condition) validate(path string) error {
+	if c.PlayedCards == nil && c.Money == nil && c.DiscardsUsed == nil && c.HandsLeft == nil &&
+		!c.FinalHand && len(c.AnyOf) == 0 && c.Not == nil {
+		return fmt.Errorf("%s: is empty (expected played_cards, money, discards_used, hands_left, final_hand, any_of or not)", path)
+	}
+	bounds := []struct {
+		name string
+		b    *Bounds
+	}{
+		{"played_cards", c.PlayedCards}, {"money", c.Money},
+		{"discards_used", c.DiscardsUsed}, {"hands_left", c.HandsLeft},
+	}
+	for _, f := range bounds {
+		if f.b == nil {
+			continue
+		}
+		if err := f.b.validate(path + "." + f.name); err != nil {
+			return err
+		}
+	}
+	for i := range c.AnyOf {
+		if err := c.AnyOf[i].validate(fmt.Sprintf("%s.any_of[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		return c.Not.validate(path + ".not")
+	}
+	return nil
+}
+
+// Card parities for CardCondition; face cards are neither
+const (
+	ParityEven = "even"
+	ParityOdd  = "odd"
+)
+
+// CardCondition limits an effect or counter update to certain cards. Every
+// field that's set must hold; any_of holds when one of its conditions does,
+// and not holds when its condition doesn't.
+type CardCondition struct {
+	// Suits and Ranks hold when the card is any of those listed, e.g.
+	// ["Hearts", "Diamonds"] or ["A", "10"]
+	Suits []string `yaml:"suits"`
+	Ranks []string `yaml:"ranks"`
+	// Parity is "even" (10, 8, 6, 4, 2) or "odd" (A, 9, 7, 5, 3)
+	Parity string `yaml:"parity"`
+	Face   *bool  `yaml:"face"`
+	// Numbered holds for 2 through 10
+	Numbered *bool `yaml:"numbered"`
+	// Enhancements and Seals hold when the card has any of those listed
+	Enhancements []string        `yaml:"enhancements"`
+	Seals        []string        `yaml:"seals"`
+	AnyOf        []CardCondition `yaml:"any_of"`
+	Not          *CardCondition  `yaml:"not"`
+}
+
+// matches reports whether the card satisfies the condition, with suits and
+// face cards read through the jokers' card modifiers
+func (c *CardCondition) matches(card Card, m CardModifiers) bool {
+	if len(c.Suits) > 0 && !m.hasAnySuit(card, c.Suits) {
+		return false
+	}
+	if len(c.Ranks) > 0 && !containsFold(c.Ranks, card.Rank.String()) {
+		return false
+	}
+	if c.Parity != "" && rankParity(card.Rank) != strings.ToLower(c.Parity) {
+		return false
+	}
+	if c.Face != nil && m.isFace(card) != *c.Face {
+		return false
+	}
+	if c.Numbered != nil && isNumbered(card) != *c.Numbered {
+		return false
+	}
+	if len(c.Enhancements) > 0 && !containsFold(c.Enhancements, card.Enhancement()) {
+		return false
+	}
+	if len(c.Seals) > 0 && !containsFold(c.Seals, string(card.Seal)) {
+		return false
+	}
+	if len(c.AnyOf) > 0 {
+		any := false
+		for i := range c.AnyOf {
+			if c.AnyOf[i].matches(card, m) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	return c.Not == nil || !c.Not.matches(card, m)
+}
+
+// validate checks the card condition and everything nested in it, naming
+// the offending field in errors
+func (c *CardCondition) validate(path string) error {
+	if len(c.Suits) == 0 && len(c.Ranks) == 0 && c.Parity == "" && c.Face == nil && c.Numbered == nil &&
+		len(c.Enhancements) == 0 && len(c.Seals) == 0 && len(c.AnyOf) == 0 && c.Not == nil {
+		return fmt.Errorf("%s: is empty (expected suits, ranks, parity, face, numbered, enhancements, seals, any_of or not)", path)
+	}
+	for i, name := range c.Suits {
+		if _, err := ParseSuit(name); err != nil {
+			return fmt.Errorf("%s.suits[%d]: %v", path, i, err)
+		}
+	}
+	for i, name := range c.Ranks {
+		if _, err := ParseRank(name); err != nil {
+			return fmt.Errorf("%s.ranks[%d]: %v (expected A, 2-10, J, Q or K)", path, i, err)
+		}
+	}
+	if p := strings.ToLower(c.Parity); p != "" && p != ParityEven && p != ParityOdd {
+		return fmt.Errorf("%s.parity: unknown parity %q (expected even or odd)", path, c.Parity)
+	}
+	for i, name := range c.Enhancements {
+		if !containsFold(enhancements, name) {
+			return fmt.Errorf("%s.enhancements[%d]: unknown enhancement %q (expected %s)", path, i, name, strings.Join(enhancements, ", "))
+		}
+	}
+	for i, name := range c.Seals {
+		if _, err := ParseSeal(name); err != nil {
+			return fmt.Errorf("%s.seals[%d]: %v", path, i, err)
+		}
+	}
+	for i := range c.AnyOf {
+		if err := c.AnyOf[i].validate(fmt.Sprintf("%s.any_of[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	if c.Not != nil {
+		return c.Not.validate(path + ".not")
+	}
+	return nil
+}
+
+// validateRules checks the conditions in a set of matching rules for
+// something firing on trigger; prefix locates them in jokers.yaml
+func validateRules(r matchRules, trigger JokerTrigger, prefix string) error {
+	if r.card != "" {
+		if err := validateCardRule(r.card); err != nil {
+			return fmt.Errorf("%scard_matching_rule: %v", prefix, err)
+		}
+	}
+	if r.condition != nil {
+		if err := r.condition.validate(prefix + "condition"); err != nil {
+			return err
+		}
+	}
+	if r.cardCondition != nil {
+		if !trigger.hasCards() {
+			return fmt.Errorf("%scard_condition: %s has no cards to check", prefix, trigger)
+		}
+		if err := r.cardCondition.validate(prefix + "card_condition"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rankParity returns "even" or "odd" for number cards and Aces, and an
+// empty string for face cards
+func rankParity(r Rank) string {
+	switch {
+	case r >= Jack:
+		return ""
+	case r%2 == 0:
+		return ParityEven
+	default:
+		return ParityOdd
+	}
+}
+
+// isFaceCard reports whether the card is a Jack, Queen or King
+func isFaceCard(card Card) bool {
+	return card.Rank == Jack || card.Rank == Queen || card.Rank == King
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+func intPtr(n int) *int { return &n }
+
+func boolPtr(b bool) *bool { return &b }
+
+// TestConditionHolds verifies game state conditions, including any_of and not.
+func TestConditionHolds(t *testing.T) {
+	state := ConditionState{HandType: "Pair", PlayedCards: 2, Money: 25, DiscardsUsed: 0, HandsLeft: 1}
+	cases := []struct {
+		name string
+		cond Condition
+		want bool
+	}{
+		{"three or fewer cards", Condition{PlayedCards: &Bounds{Max: intPtr(3)}}, true},
+		{"at least $30", Condition{Money: &Bounds{Min: intPtr(30)}}, false},
+		{"no discards used", Condition{DiscardsUsed: &Bounds{Max: intPtr(0)}}, true},
+		{"final hand", Condition{FinalHand: true}, true},
+		{"all must hold", Condition{FinalHand: true, Money: &Bounds{Min: intPtr(30)}}, false},
+		{"any of", Condition{AnyOf: []Condition{{Money: &Bounds{Min: intPtr(30)}}, {FinalHand: true}}}, true},
+		{"not", Condition{Not: &Condition{FinalHand: true}}, false},
+	}
+	for _, c := range cases {
+		if got := c.cond.holds(state); got != c.want {
+			t.Errorf("%s: holds = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	outside := ConditionState{HandsLeft: 1}
+	if (&Condition{FinalHand: true}).holds(outside) {
+		t.Error("expected final_hand not to hold outside of a hand")
+	}
+}
+
+// TestCardConditionMatches verifies card conditions on suit, rank, parity
+// and face cards.
+func TestCardConditionMatches(t *testing.T) {
+	isFace := true
+	red := CardCondition{Suits: []string{"Hearts", "diamonds"}}
+	even := CardCondition{Parity: "even"}
+	odd := CardCondition{Parity: "odd"}
+	cases := []struct {
+		name string
+		cond CardCondition
+		card Card
+		want bool
+	}{
+		{"heart is red", red, Card{Rank: Two, Suit: Hearts}, true},
+		{"diamond is red", red, Card{Rank: Two, Suit: Diamonds}, true},
+		{"spade isn't red", red, Card{Rank: Two, Suit: Spades}, false},
+		{"ten is even", even, Card{Rank: Ten, Suit: Clubs}, true},
+		{"ace is odd", odd, Card{Rank: Ace, Suit: Clubs}, true},
+		{"king is neither", even, Card{Rank: King, Suit: Clubs}, false},
+		{"king is neither odd", odd, Card{Rank: King, Suit: Clubs}, false},
+		{"ranks", CardCondition{Ranks: []string{"A", "10"}}, Card{Rank: Ten, Suit: Clubs}, true},
+		{"face", CardCondition{Face: &isFace}, Card{Rank: Jack, Suit: Clubs}, true},
+		{"red face", CardCondition{Suits: []string{"Hearts"}, Face: &isFace}, Card{Rank: Queen, Suit: Spades}, false},
+		{"any of", CardCondition{AnyOf: []CardCondition{even, {Ranks: []string{"K"}}}}, Card{Rank: King, Suit: Clubs}, true},
+		{"not", CardCondition{Not: &red}, Card{Rank: Two, Suit: Clubs}, true},
+	}
+	for _, c := range cases {
+		if got := c.cond.matches(c.card, CardModifiers{}); got != c.want {
+			t.Errorf("%s: matches(%s) = %v, want %v", c.name, c.card, got, c.want)
+		}
+	}
+}
+
+// TestValidateConditions verifies bad conditions are reported with the
+// joker's name and the field at fault.
+func TestValidateConditions(t *testing.T) {
+	cases := []struct {
+		config JokerConfig
+		want   string
+	}{
+		{JokerConfig{Name: "Half Joker", Effect: AddMult, Condition: &Condition{}},
+			"condition: is empty"},
+		{JokerConfig{Name: "Bull", Effect: AddChips, Condition: &Condition{Money: &Bounds{Min: intPtr(20), Max: intPtr(10)}}},
+			"condition.money: min 20 is above max 10"},
+		{JokerConfig{Name: "Bull", Effect: AddChips, Condition: &Condition{Not: &Condition{Money: &Bounds{}}}},
+			"condition.not.money: needs min or max"},
+		{JokerConfig{Name: "Gems", Effects: []JokerEffectConfig{
+			{Effect: AddChips, EffectMagnitude: 10},
+			{Effect: AddMult, EffectMagnitude: 4, CardCondition: &CardCondition{Suits: []string{"Hearts", "Hart"}}},
+		}}, `effects[1].card_condition.suits[1]: unknown suit "Hart"`},
+		{JokerConfig{Name: "Aces", Effect: AddMult, CardCondition: &CardCondition{AnyOf: []CardCondition{{Ranks: []string{"1"}}}}},
+			`card_condition.any_of[0].ranks[0]: unknown rank "1"`},
+		{JokerConfig{Name: "Even Steven", Effect: AddMult, CardCondition: &CardCondition{Parity: "evens"}},
+			`card_condition.parity: unknown parity "evens"`},
+		{JokerConfig{Name: "Rough Gem", Effect: AddMoney, CardCondition: &CardCondition{Suits: []string{"Diamonds"}}},
+			"card_condition: blind_end has no cards to check"},
+		{JokerConfig{Name: "Juggler", Effect: AddHandSize, Condition: &Condition{FinalHand: true}},
+			"AddHandSize always applies"},
+		{JokerConfig{Name: "Green Joker", Effect: AddMult, Counter: &JokerCounter{Updates: []CounterUpdate{
+			{On: TriggerHandPlayed, Add: 1, Condition: &Condition{PlayedCards: &Bounds{}}},
+		}}}, "counter.updates[0].condition.played_cards: needs min or max"},
+	}
+	for _, c := range cases {
+		err := validateJokerConfig(c.config)
+		if err == nil || !strings.Contains(err.Error(), "joker "+c.config.Name+":") || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("validateJokerConfig(%s) = %v, want an error mentioning %q", c.config.Name, err, c.want)
+		}
+	}
+}
+
+// TestParseJokersYAMLRejectsUnknownFields verifies a misspelled condition
+// key fails to load with the joker and field named, even next to a valid key.
+func TestParseJokersYAMLRejectsUnknownFields(t *testing.T) {
+	for _, condition := range []string{
+		"{money: {min: 20}, discard_used: {max: 0}}",
+		"{discard_used: {max: 0}}",
+	} {
+		_, err := parseJokersYAML([]byte(`
+jokers:
+  - name: Banker
+    effect: AddMult
+    effect_magnitude: 4
+    condition: ` + condition + "\n"))
+		if err == nil || !strings.Contains(err.Error(), "joker Banker:") || !strings.Contains(err.Error(), "field discard_used") {
+			t.Errorf("condition %s: got %v, want an error naming Banker and discard_used", condition, err)
+		}
+	}
+
+	config, err := parseJokersYAML([]byte(`
+jokers:
+  - name: Banker
+    effect: AddMult
+    effect_magnitude: 4
+    condition: {money: {min: 20}, discards_used: {max: 0}}
+`))
+	if err != nil || config.Jokers[0].Condition.DiscardsUsed == nil {
+		t.Fatalf("expected the correctly spelled condition to load, got %+v, %v", config, err)
+	}
+}
+
+// TestConditionalJokersInPlay verifies conditions are checked against the
+// game as hands are played and blinds end.
+func TestConditionalJokersInPlay(t *testing.T) {
+	LoadConfig()
+	halfJoker := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Half Joker", Effect: AddMult, EffectMagnitude: 20, Condition: &Condition{PlayedCards: &Bounds{Max: intPtr(3)}}}))
+	roughGem := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Rough Gem", Effect: AddMoney, EffectMagnitude: 1, Trigger: TriggerCardScored, CardCondition: &CardCondition{Suits: []string{"Diamonds"}}}))
+	play := func(selection []string) (HandPlayedEvent, *Game) {
+		handler := &testEventHandler{}
+		g := &Game{
+			deck:      NewDeck(),
+			deckIndex: InitialCards,
+			playerCards: []Card{
+				{Rank: Two, Suit: Diamonds}, {Rank: Five, Suit: Diamonds}, {Rank: Nine, Suit: Clubs},
+				{Rank: Jack, Suit: Spades}, {Rank: King, Suit: Hearts},
+			},
+			handLevels:   map[string]int{},
+			jokers:       []OwnedJoker{halfJoker, roughGem},
+			eventEmitter: NewEventEmitter(),
+		}
+		g.eventEmitter.SetEventHandler(handler)
+		g.handlePlayAction(selection)
+		for _, e := range handler.events {
+			if hp, ok := e.(HandPlayedEvent); ok {
+				return hp, g
+			}
+		}
+		t.Fatal("no hand played")
+		return HandPlayedEvent{}, nil
+	}
+
+	short, g := play([]string{"1", "2", "3"})
+	if short.JokerMult != 20 || g.money != 2 {
+		t.Fatalf("three cards: got +%d Mult and $%d, want +20 Mult and $2 from two Diamonds", short.JokerMult, g.money)
+	}
+	long, _ := play([]string{"1", "2", "3", "4"})
+	if long.JokerMult != 0 {
+		t.Errorf("four cards: expected Half Joker not to apply, got +%d Mult", long.JokerMult)
+	}
+
+	delayed := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Delayed Gratification", Effect: AddMoney, EffectMagnitude: 4, Condition: &Condition{DiscardsUsed: &Bounds{Max: intPtr(0)}}}))
+	if lines := jokerMoneyLines([]OwnedJoker{delayed}, TriggerBlindEnd, ConditionState{}, nil); sumRewards(lines) != 4 {
+		t.Errorf("expected $4 with no discards used, got %+v", lines)
+	}
+	if lines := jokerMoneyLines([]OwnedJoker{delayed}, TriggerBlindEnd, ConditionState{DiscardsUsed: 1}, nil); len(lines) != 0 {
+		t.Errorf("expected nothing after a discard, got %+v", lines)
+	}
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"encoding/csv"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -22,10 +23,40 @@ type HandScore struct {
 	Multiplier  int
 }
 
+// EndlessScaling controls how blind targets grow past the last configured ante.
+// Each extra ante k multiplies the last configured target by Growth^(k^Exponent).
+type EndlessScaling struct {
+	Growth   float64
+	Exponent float64
+}
+
+// Default endless scaling used when endless_scaling.csv is missing
+const (
+	DefaultEndlessGrowth   = 2.0
+	DefaultEndlessExponent = 1.2
+)
+
+// Interest controls the money paid for holding cash when a blind is defeated.
+// Every Step dollars held pays Amount, up to Cap dollars per blind.
+type Interest struct {
+	Step   int
+	Amount int
+	Cap    int
+}
+
+// Default interest used when interest.csv is missing
+const (
+	DefaultInterestStep   = 5
+	DefaultInterestAmount = 1
+	DefaultInterestCap    = 5
+)
+
 // Config holds all game configuration loaded from CSV files
 type Config struct {
 	AnteRequirements []AnteRequirement
 	HandScores       map[string]HandScore
+	EndlessScaling   EndlessScaling
+	Interest         Interest
 }
 
 var gameConfig *Config
@@ -48,6 +79,18 @@ func LoadConfig() error {
 		config.setDefaultHandScores()
 	}
 
+	// Load endless scaling
+	if err := config.loadEndlessScaling(); err != nil {
+		fmt.Printf("Warning: Could not load endless_scaling.csv, using defaults: %v\n", err)
+		config.setDefaultEndlessScaling()
+	}
+
+	// Load interest
+	if err := config.loadInterest(); err != nil {
+		fmt.Printf("Warning: Could not load interest.csv, using defaults: %v\n", err)
+		config.setDefaultInterest()
+	}
+
 	gameConfig = config
 	return nil
 }
@@ -158,6 +201,75 @@ func (c *Config) loadHandScores() error {
 	return nil
 }
 
+// loadEndlessScaling loads the endless mode target formula from CSV file
+func (c *Config) loadEndlessScaling() error {
+	file, err := os.Open(filepath.Join("internal", "game", "endless_scaling.csv"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	if len(records) != 2 || len(records[1]) != 2 {
+		return fmt.Errorf("endless_scaling.csv must have a header and one row with growth and exponent")
+	}
+
+	growth, err := strconv.ParseFloat(records[1][0], 64)
+	if err != nil || growth < 1 {
+		return fmt.Errorf("invalid growth value %q", records[1][0])
+	}
+
+	exponent, err := strconv.ParseFloat(records[1][1], 64)
+	if err != nil || exponent <= 0 {
+		return fmt.Errorf("invalid exponent value %q", records[1][1])
+	}
+
+	c.EndlessScaling = EndlessScaling{Growth: growth, Exponent: exponent}
+	return nil
+}
+
+// loadInterest loads the interest rate and cap from CSV file
+func (c *Config) loadInterest() error {
+	file, err := os.Open(filepath.Join("internal", "game", "interest.csv"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	if len(records) != 2 || len(records[1]) != 3 {
+		return fmt.Errorf("interest.csv must have a header and one row with step, amount and cap")
+	}
+
+	step, err := strconv.Atoi(records[1][0])
+	if err != nil || step < 1 {
+		return fmt.Errorf("invalid step value %q", records[1][0])
+	}
+
+	amount, err := strconv.Atoi(records[1][1])
+	if err != nil || amount < 0 {
+		return fmt.Errorf("invalid amount value %q", records[1][1])
+	}
+
+	interestCap, err := strconv.Atoi(records[1][2])
+	if err != nil || interestCap < 0 {
+		return fmt.Errorf("invalid cap value %q", records[1][2])
+	}
+
+	c.Interest = Interest{Step: step, Amount: amount, Cap: interestCap}
+	return nil
+}
+
 // setDefaultAnteRequirements sets hardcoded default ante requirements
 func (c *Config) setDefaultAnteRequirements() {
 	c.AnteRequirements = []AnteRequirement{
@@ -172,6 +284,16 @@ func (c *Config) setDefaultAnteRequirements() {
 	}
 }
 
+// setDefaultEndlessScaling sets hardcoded default endless scaling
+func (c *Config) setDefaultEndlessScaling() {
+	c.EndlessScaling = EndlessScaling{Growth: DefaultEndlessGrowth, Exponent: DefaultEndlessExponent}
+}
+
+// setDefaultInterest sets hardcoded default interest
+func (c *Config) setDefaultInterest() {
+	c.Interest = Interest{Step: DefaultInterestStep, Amount: DefaultInterestAmount, Cap: DefaultInterestCap}
+}
+
 // setDefaultHandScores sets hardcoded default hand scores
 func (c *Config) setDefaultHandScores() {
 	defaults := []HandScore{
@@ -194,6 +316,9 @@ func (c *Config) setDefaultHandScores() {
 
 // GetAnteRequirement returns the score requirements for a specific ante and blind type
 func GetAnteRequirement(ante int, blindType BlindType) int {
+	if gameConfig != nil && len(gameConfig.AnteRequirements) > 0 && ante > len(gameConfig.AnteRequirements) {
+		return endlessRequirement(ante, blindType)
+	}
 	if gameConfig == nil || ante < 1 || ante > len(gameConfig.AnteRequirements) {
 		// Fallback to original calculation
 		base := 300
@@ -224,6 +349,26 @@ func GetAnteRequirement(ante int, blindType BlindType) int {
 	}
 }
 
+// endlessRequirement scales the last configured ante's target for antes beyond the table
+func endlessRequirement(ante int, blindType BlindType) int {
+	last := len(gameConfig.AnteRequirements)
+	base := GetAnteRequirement(last, blindType)
+	scaling := gameConfig.EndlessScaling
+	if scaling.Growth == 0 {
+		scaling = EndlessScaling{Growth: DefaultEndlessGrowth, Exponent: DefaultEndlessExponent}
+	}
+	extra := float64(ante - last)
+	return int(ScoreFromFloat(float64(base) * math.Pow(scaling.Growth, math.Pow(extra, scaling.Exponent))))
+}
+
+// GetInterest returns the configured interest rate and base cap
+func GetInterest() Interest {
+	if gameConfig == nil || gameConfig.Interest.Step == 0 {
+		return Interest{Step: DefaultInterestStep, Amount: DefaultInterestAmount, Cap: DefaultInterestCap}
+	}
+	return gameConfig.Interest
+}
+
 // GetHandScore returns the base score for a specific level and multiplier for a hand type
 func GetHandScore(handName string, level int) (int, int) {
 	if level < 1 {
@@ -0,0 +1,296 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ConsumableType identifies the kind of single-use card
+type ConsumableType string
+
+const (
+	// PlanetCard levels up a poker hand
+	PlanetCard ConsumableType = "Planet"
+	// TarotCard has a small one-off effect
+	TarotCard ConsumableType = "Tarot"
+	// SpectralCard has a powerful effect, often with a drawback
+	SpectralCard ConsumableType = "Spectral"
+)
+
+// Consumable slots and prices
+const (
+	DefaultConsumableSlots = 2
+	PlanetPrice            = 3
+	TarotPrice             = 3
+	SpectralPrice          = 4
+)
+
+// Tarot and Spectral card limits
+const (
+	HermitMax     = 20
+	TemperanceMax = 50
+	TarotCopies   = 2
+)
+
+// Consumable is a single-use card held until the player uses it
+type Consumable struct {
+	Type ConsumableType
+	Name string
+	// Hand is the poker hand a Planet card levels up
+	Hand string
+	// Text describes what a Tarot or Spectral card does
+	Text  string
+	Price int
+}
+
+// Description returns what the consumable does when used
+func (c Consumable) Description() string {
+	switch c.Type {
+	case PlanetCard:
+		return fmt.Sprintf("Level up %s", c.Hand)
+	default:
+		return c.Text
+	}
+}
+
+// planetCards lists the Planet cards and the hand each one levels up
+var planetCards = []Consumable{
+	{Type: PlanetCard, Name: "Pluto", Hand: "High Card", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Mercury", Hand: "Pair", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Uranus", Hand: "Two Pair", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Venus", Hand: "Three of a Kind", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Saturn", Hand: "Straight", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Jupiter", Hand: "Flush", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Earth", Hand: "Full House", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Mars", Hand: "Four of a Kind", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Neptune", Hand: "Straight Flush", Price: PlanetPrice},
+	{Type: PlanetCard, Name: "Eris", Hand: "Royal Flush", Price: PlanetPrice},
+}
+
+// tarotCards lists the Tarot cards. Only Tarots that don't target cards in
+// hand exist so far.
+var tarotCards = []Consumable{
+	{Type: TarotCard, Name: "The Hermit", Text: fmt.Sprintf("Double your money (max $%d)", HermitMax), Price: TarotPrice},
+	{Type: TarotCard, Name: "Temperance", Text: fmt.Sprintf("Gain the sell value of your jokers (max $%d)", TemperanceMax), Price: TarotPrice},
+	{Type: TarotCard, Name: "Judgement", Text: "Create a random Joker", Price: TarotPrice},
+	{Type: TarotCard, Name: "The High Priestess", Text: fmt.Sprintf("Create up to %d random Planet cards", TarotCopies), Price: TarotPrice},
+	{Type: TarotCard, Name: "The Emperor", Text: fmt.Sprintf("Create up to %d random Tarot cards", TarotCopies), Price: TarotPrice},
+}
+
+// spectralCards lists the Spectral cards
+var spectralCards = []Consumable{
+	{Type: SpectralCard, Name: "Black Hole", Text: "Level up every poker hand", Price: SpectralPrice},
+	{Type: SpectralCard, Name: "Wraith", Text: "Create a random Rare Joker, sets money to $0", Price: SpectralPrice},
+}
+
+// GetPlanetCards returns every Planet card
+func GetPlanetCards() []Consumable {
+	return append([]Consumable{}, planetCards...)
+}
+
+// GetTarotCards returns every Tarot card
+func GetTarotCards() []Consumable {
+	return append([]Consumable{}, tarotCards...)
+}
+
+// GetSpectralCards returns every Spectral card
+func GetSpectralCards() []Consumable {
+	return append([]Consumable{}, spectralCards...)
+}
+
+// GetConsumableByName returns a consumable by its name if it exists
+func GetConsumableByName(name string) (Consumable, bool) {
+	for _, cards := range [][]Consumable{planetCards, tarotCards, spectralCards} {
+		for _, c := range cards {
+			if c.Name == name {
+				return c, true
+			}
+		}
+	}
+	return Consumable{}, false
+}
+
+// consumableSlots returns how many consumables the player can hold
+func (g *Game) consumableSlots() int {
+	return DefaultConsumableSlots + g.voucherBonus(VoucherConsumableSlots)
+}
+
+// addConsumable gives the player a consumable if they have room, reporting
+// whether it was added
+func (g *Game) addConsumable(c Consumable) bool {
+	if len(g.consumables) >= g.consumableSlots() {
+		return false
+	}
+	g.consumables = append(g.consumables, c)
+	return true
+}
+
+// consumableBlocked explains why a consumable can't be used right now, or
+// returns an empty string when it can
+func (g *Game) consumableBlocked(c Consumable) string {
+	switch c.Name {
+	case "Judgement", "Wraith":
+		if g.jokerSlotsUsed() >= g.jokerSlots() {
+			return g.noJokerRoomReason("a new joker")
+		}
+	}
+	return ""
+}
+
+// useConsumable applies a consumable's effect and returns the events that
+// describe it
+func (g *Game) useConsumable(c Consumable) []Event {
+	var message string
+	switch c.Name {
+	case "The Hermit":
+		gain := g.money
+		if gain > HermitMax {
+			gain = HermitMax
+		}
+		if gain < 0 {
+			gain = 0
+		}
+		g.money += gain
+		message = fmt.Sprintf("+$%d", gain)
+	case "Temperance":
+		gain := 0
+		for _, joker := range g.jokers {
+			gain += joker.SellValue()
+		}
+		if gain > TemperanceMax {
+			gain = TemperanceMax
+		}
+		g.money += gain
+		message = fmt.Sprintf("+$%d", gain)
+	case "Judgement":
+		joker, ok := g.rollJoker(nil, GetShopConfig().RarityWeights)
+		if !ok {
+			return []Event{InvalidActionEvent{Action: "use_consumable", Reason: "no joker left to create"}}
+		}
+		message = g.createJoker(joker)
+	case "Wraith":
+		joker, ok := g.rollJoker(nil, map[string]int{RarityRare: 1})
+		if !ok {
+			return []Event{InvalidActionEvent{Action: "use_consumable", Reason: "no Rare joker left to create"}}
+		}
+		g.money = 0
+		message = g.createJoker(joker) + ", money set to $0"
+	case "The High Priestess":
+		message = g.createConsumables(planetCards)
+	case "The Emperor":
+		message = g.createConsumables(tarotCards)
+	case "Black Hole":
+		for _, eval := range handEvaluators {
+			g.LevelUpHand(eval.Name())
+		}
+		message = "every poker hand leveled up"
+	default:
+		if c.Type != PlanetCard {
+			return []Event{InvalidActionEvent{Action: "use_consumable", Reason: fmt.Sprintf("%s has no effect", c.Name)}}
+		}
+		g.LevelUpHand(c.Hand)
+		g.updateJokerCounters(TriggerPlanetUsed, g.conditionState("", nil), nil)
+		message = fmt.Sprintf("%s is now level %d", c.Hand, g.handLevels[c.Hand])
+	}
+	return []Event{MessageEvent{Message: fmt.Sprintf("%s: %s", c.Name, message), Type: "success"}}
+}
+
+// createJoker gives the player a joker made by a consumable and describes it
+func (g *Game) createJoker(joker Joker) string {
+	g.jokers = append(g.jokers, newOwnedJoker(joker))
+	return fmt.Sprintf("created %s", joker.Name)
+}
+
+// createConsumables fills free consumable slots, up to TarotCopies, with
+// random cards from the given list and describes what was made
+func (g *Game) createConsumables(from []Consumable) string {
+	var made []string
+	for i := 0; i < TarotCopies; i++ {
+		c := from[g.shopRng().Intn(len(from))]
+		if !g.addConsumable(c) {
+			break
+		}
+		made = append(made, c.Name)
+	}
+	if len(made) == 0 {
+		return "no room for new consumables"
+	}
+	return "created " + strings.Join(made, ", ")
+}
+
+// consumablePool returns the consumables a CreateConsumable joker can make:
+// every card of a type such as "Tarot", or the one card with that name
+func consumablePool(name string) ([]Consumable, error) {
+	switch ConsumableType(name) {
+	case PlanetCard:
+		return planetCards, nil
+	case TarotCard:
+		return tarotCards, nil
+	case SpectralCard:
+		return spectralCards, nil
+	}
+	if c, ok := GetConsumableByName(name); ok {
+		return []Consumable{c}, nil
+	}
+	return nil, fmt.Errorf("unknown consumable %q (expected Planet, Tarot, Spectral or a card's name)", name)
+}
+
+// jokerCreateConsumables fills free consumable slots with the consumables a
+// CreateConsumable step makes, one per point of magnitude
+func (g *Game) jokerCreateConsumables(step ScoringStep) {
+	pool, err := consumablePool(step.Consumable)
+	if err != nil {
+		return
+	}
+	count := step.Amount
+	if count < 1 {
+		count = 1
+	}
+	var made []string
+	for i := 0; i < count; i++ {
+		c := pool[g.shopRng().Intn(len(pool))]
+		if !g.addConsumable(c) {
+			break
+		}
+		made = append(made, c.Name)
+	}
+	if len(made) > 0 {
+		g.eventEmitter.EmitInfo(fmt.Sprintf("%s: created %s", step.Joker, strings.Join(made, ", ")))
+	}
+}
+
+// handleUseConsumableAction uses and removes one of the player's consumables
+func (g *Game) handleUseConsumableAction(params []string) {
+	if len(params) != 1 {
+		g.eventEmitter.EmitEvent(InvalidActionEvent{
+			Action: "use_consumable",
+			Reason: "Usage: use <index>",
+		})
+		return
+	}
+
+	idx, err := strconv.Atoi(params[0])
+	if err != nil || idx < 1 || idx > len(g.consumables) {
+		g.eventEmitter.EmitEvent(InvalidActionEvent{
+			Action: "use_consumable",
+			Reason: fmt.Sprintf("Invalid consumable number: %s", params[0]),
+		})
+		return
+	}
+
+	used := g.consumables[idx-1]
+	if reason := g.consumableBlocked(used); reason != "" {
+		g.eventEmitter.EmitEvent(InvalidActionEvent{Action: "use_consumable", Reason: reason})
+		return
+	}
+	// Take the card out first so cards it creates can use its slot, and put
+	// it back if it turns out to have no effect
+	g.consumables = append(g.consumables[:idx-1], g.consumables[idx:]...)
+	events := g.useConsumable(used)
+	if _, failed := events[0].(InvalidActionEvent); failed {
+		g.consumables = append(g.consumables[:idx-1], append([]Consumable{used}, g.consumables[idx-1:]...)...)
+	}
+	g.emitEvents(events)
+	g.emitGameState()
+}
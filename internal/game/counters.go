@@ -0,0 +1,141 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JokerCounter makes a joker scale over the run: its counter is added to
+// the magnitude of each of its effects
+type JokerCounter struct {
+	// Min is the lowest the counter can pull any of the joker's effect
+	// magnitudes down to
+	Min     int             `yaml:"min"`
+	Updates []CounterUpdate `yaml:"updates"`
+}
+
+// CounterUpdate changes a joker's counter when its trigger fires and the
+// played hand matches its rules
+type CounterUpdate struct {
+	On JokerTrigger `yaml:"on"`
+	// Add is added to the counter; Reset sets it back to 0 instead
+	Add   int  `yaml:"add"`
+	Reset bool `yaml:"reset"`
+	// HandMatchingRule limits hand triggers to matching hands
+	HandMatchingRule HandMatchingRule `yaml:"hand_matching_rule"`
+	// CardMatchingRule limits card triggers to matching cards, and hand
+	// triggers to hands with at least one matching card
+	CardMatchingRule CardMatchingRule `yaml:"card_matching_rule"`
+	// Condition and CardCondition add further game state and card checks
+	Condition     *Condition     `yaml:"condition"`
+	CardCondition *CardCondition `yaml:"card_condition"`
+}
+
+// validateCounter checks a joker's counter configuration
+func validateCounter(name string, counter *JokerCounter) error {
+	if counter == nil {
+		return nil
+	}
+	if len(counter.Updates) == 0 {
+		return fmt.Errorf("joker %s: counter has no updates", name)
+	}
+	for i, u := range counter.Updates {
+		if !containsString(jokerTriggers, string(u.On)) {
+			return fmt.Errorf("joker %s: counter update %d: unknown trigger %q (expected one of %s)", name, i+1, u.On, strings.Join(jokerTriggers, ", "))
+		}
+		if u.Add == 0 && !u.Reset {
+			return fmt.Errorf("joker %s: counter update %d: needs add or reset", name, i+1)
+		}
+		if err := validateRules(u.rules(), u.On, fmt.Sprintf("counter.updates[%d].", i)); err != nil {
+			return fmt.Errorf("joker %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// effectMagnitude returns an effect's magnitude including the joker's
+// counter, which can't pull it below the counter's minimum
+func (j OwnedJoker) effectMagnitude(eff JokerEffectConfig) int {
+	magnitude := eff.EffectMagnitude + j.Counter
+	if j.CounterRules == nil {
+		return magnitude
+	}
+	if floor := min(j.CounterRules.Min, eff.EffectMagnitude); magnitude < floor {
+		return floor
+	}
+	return magnitude
+}
+
+// CounterLabel describes a scaling joker's current bonus, e.g.
+// "currently +12 Mult", or returns an empty string for other jokers
+func (j OwnedJoker) CounterLabel() string {
+	if j.CounterRules == nil || len(j.Effects) == 0 {
+		return ""
+	}
+	eff := j.Effects[0]
+	magnitude := j.effectMagnitude(eff)
+	switch eff.Effect {
+	case AddChips:
+		return fmt.Sprintf("currently +%d Chips", magnitude)
+	case AddMult:
+		return fmt.Sprintf("currently +%d Mult", magnitude)
+	case MultiplyMult:
+		return fmt.Sprintf("currently ×%d Mult", magnitude)
+	case AddMoney:
+		return fmt.Sprintf("currently $%d", magnitude)
+	default:
+		return fmt.Sprintf("currently %d", magnitude)
+	}
+}
+
+// applyUpdate changes the joker's counter. It stops falling once every
+// effect is at the counter's minimum, so it doesn't have to climb back up
+// from far below.
+func (j *OwnedJoker) applyUpdate(u CounterUpdate) {
+	if u.Reset {
+		j.Counter = 0
+	} else {
+		j.Counter += u.Add
+	}
+	if len(j.Effects) == 0 {
+		return
+	}
+	highest := j.Effects[0].EffectMagnitude
+	for _, eff := range j.Effects[1:] {
+		highest = max(highest, eff.EffectMagnitude)
+	}
+	if floor := j.CounterRules.Min - highest; j.Counter < floor {
+		j.Counter = floor
+	}
+}
+
+// updateJokerCounters fires a trigger for every active scaling joker. Hand
+// triggers pass the played hand's state and cards; other triggers pass no
+// cards.
+func (g *Game) updateJokerCounters(trigger JokerTrigger, state ConditionState, cards []Card) {
+	state.Cards = cardModifiers(g.jokers)
+	for i := range g.jokers {
+		joker := &g.jokers[i]
+		if joker.CounterRules == nil || !joker.Active() {
+			continue
+		}
+		for _, u := range joker.CounterRules.Updates {
+			if u.On != trigger {
+				continue
+			}
+			for n := counterMatches(u, state, cards); n > 0; n-- {
+				joker.applyUpdate(u)
+			}
+		}
+	}
+}
+
+// rules returns the update's matching rules
+func (u CounterUpdate) rules() matchRules {
+	return matchRules{u.HandMatchingRule, u.CardMatchingRule, u.Condition, u.CardCondition}
+}
+
+// counterMatches returns how many times an update applies
+func counterMatches(u CounterUpdate, state ConditionState, cards []Card) int {
+	return triggerMatches(u.On, u.rules(), state, cards)
+}
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// scalingJoker builds a joker with a counter for tests
+func scalingJoker(effect JokerEffect, magnitude int, updates ...CounterUpdate) OwnedJoker {
+	return newOwnedJoker(createJokerFromConfig(JokerConfig{
+		Name:            "Scaling",
+		Effect:          effect,
+		EffectMagnitude: magnitude,
+		Counter:         &JokerCounter{Updates: updates},
+	}))
+}
+
+// TestRideTheBusResetsOnFaceCards verifies a counter grows each hand and a
+// later reset update in the same trigger wins.
+func TestRideTheBusResetsOnFaceCards(t *testing.T) {
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{scalingJoker(AddMult, 0,
+		CounterUpdate{On: TriggerHandPlayed, Add: 1},
+		CounterUpdate{On: TriggerHandPlayed, CardMatchingRule: CardIsFace, Reset: true},
+	)}
+	plain := []Card{{Rank: Two, Suit: Hearts}}
+	face := []Card{{Rank: King, Suit: Hearts}}
+
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "High Card"}, plain)
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "High Card"}, plain)
+	if _, mult, _ := CalculateJokerHandBonus(g.jokers, "High Card", plain); mult != 2 {
+		t.Fatalf("expected +2 Mult after two plain hands, got %d", mult)
+	}
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "High Card"}, face)
+	if g.jokers[0].Counter != 0 {
+		t.Fatalf("expected a face card to reset the counter, got %d", g.jokers[0].Counter)
+	}
+}
+
+// TestCounterMinimum verifies counters can't push a joker below its minimum.
+func TestCounterMinimum(t *testing.T) {
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{
+		scalingJoker(AddMult, 0, CounterUpdate{On: TriggerHandPlayed, Add: 1}, CounterUpdate{On: TriggerDiscard, Add: -1}),
+		scalingJoker(AddChips, 100, CounterUpdate{On: TriggerHandScored, Add: -5}),
+	}
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "Pair"}, nil)
+	for i := 0; i < 3; i++ {
+		g.updateJokerCounters(TriggerDiscard, ConditionState{}, nil)
+	}
+	if g.jokers[0].Counter != 0 {
+		t.Fatalf("expected Green Joker to stop at +0 Mult, counter=%d", g.jokers[0].Counter)
+	}
+	for i := 0; i < 25; i++ {
+		g.updateJokerCounters(TriggerHandScored, ConditionState{HandType: "Pair"}, nil)
+	}
+	if got := g.jokers[1].effectMagnitude(g.jokers[1].Effects[0]); got != 0 {
+		t.Fatalf("expected Ice Cream to melt to +0 Chips, got %d", got)
+	}
+}
+
+// TestCounterMinimumEveryEffect verifies a counter can't pull any of a
+// multi-effect joker's effects below the minimum, and stops falling once
+// they are all there.
+func TestCounterMinimumEveryEffect(t *testing.T) {
+	joker := newOwnedJoker(createJokerFromConfig(JokerConfig{
+		Name: "Melting",
+		Effects: []JokerEffectConfig{
+			{Effect: AddChips, EffectMagnitude: 20},
+			{Effect: AddMult, EffectMagnitude: 4},
+		},
+		Counter: &JokerCounter{Min: 1, Updates: []CounterUpdate{{On: TriggerHandScored, Add: -5}}},
+	}))
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{joker}
+	for i := 0; i < 10; i++ {
+		g.updateJokerCounters(TriggerHandScored, ConditionState{HandType: "Pair"}, nil)
+	}
+	melted := g.jokers[0]
+	if got := melted.effectMagnitude(melted.Effects[1]); got != 1 {
+		t.Errorf("expected the Mult effect to stop at its minimum of 1, got %d", got)
+	}
+	if got := melted.effectMagnitude(melted.Effects[0]); got != 1 {
+		t.Errorf("expected the Chips effect to stop at its minimum of 1, got %d", got)
+	}
+	if melted.Counter != -19 {
+		t.Errorf("expected the counter to stop once every effect is at the minimum, counter=%d", melted.Counter)
+	}
+}
+
+// TestCounterRules verifies hand and card rules limit when updates apply.
+func TestCounterRules(t *testing.T) {
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{
+		scalingJoker(AddChips, 0, CounterUpdate{On: TriggerHandPlayed, HandMatchingRule: ContainsStraight, Add: 15}),
+		scalingJoker(AddChips, 0, CounterUpdate{On: TriggerCardScored, CardMatchingRule: CardIsAce, Add: 2}),
+	}
+	cards := []Card{{Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Hearts}, {Rank: Two, Suit: Clubs}}
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "Pair"}, cards)
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "Straight"}, cards)
+	g.updateJokerCounters(TriggerCardScored, ConditionState{HandType: "Pair"}, cards)
+	if g.jokers[0].Counter != 15 || g.jokers[1].Counter != 4 {
+		t.Fatalf("expected counters 15 and 4, got %d and %d", g.jokers[0].Counter, g.jokers[1].Counter)
+	}
+
+	g.jokers[0].Debuffed = true
+	g.updateJokerCounters(TriggerHandPlayed, ConditionState{HandType: "Straight"}, cards)
+	if g.jokers[0].Counter != 15 {
+		t.Fatalf("expected a debuffed joker not to scale, counter=%d", g.jokers[0].Counter)
+	}
+}
+
+// TestPlayingAndPlanetsUpdateCounters verifies the game fires triggers as
+// hands are played and Planet cards used, and that the bonus applies to the
+// hand that triggered it.
+func TestPlayingAndPlanetsUpdateCounters(t *testing.T) {
+	LoadConfig()
+	handler := &testEventHandler{}
+	deck := NewDeck()
+	g := &Game{
+		deck:         deck,
+		deckIndex:    InitialCards,
+		playerCards:  append([]Card{}, deck[:InitialCards]...),
+		handLevels:   map[string]int{},
+		jokers:       []OwnedJoker{scalingJoker(AddMult, 0, CounterUpdate{On: TriggerHandPlayed, Add: 1}, CounterUpdate{On: TriggerPlanetUsed, Add: 2})},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handlePlayAction([]string{"1"})
+	var played HandPlayedEvent
+	for _, e := range handler.events {
+		if hp, ok := e.(HandPlayedEvent); ok {
+			played = hp
+		}
+	}
+	if played.JokerMult != 1 {
+		t.Fatalf("expected the first hand to get +1 Mult, got %d", played.JokerMult)
+	}
+
+	mars, _ := GetConsumableByName("Mars")
+	g.useConsumable(mars)
+	if g.jokers[0].Counter != 3 {
+		t.Fatalf("expected a Planet card to add 2 to the counter, got %d", g.jokers[0].Counter)
+	}
+	if _, description := g.jokers[0].Face(); !strings.Contains(description, "currently +3 Mult") {
+		t.Errorf("description = %q, want the current bonus", description)
+	}
+}
+
+// TestValidateCounter verifies bad counters are reported with the joker name.
+func TestValidateCounter(t *testing.T) {
+	cases := []struct {
+		counter *JokerCounter
+		want    string
+	}{
+		{&JokerCounter{}, "no updates"},
+		{&JokerCounter{Updates: []CounterUpdate{{On: "on_sell", Add: 1}}}, `unknown trigger "on_sell"`},
+		{&JokerCounter{Updates: []CounterUpdate{{On: TriggerDiscard}}}, "needs add or reset"},
+	}
+	for _, c := range cases {
+		err := validateCounter("Green Joker", c.counter)
+		if err == nil || !strings.Contains(err.Error(), "Green Joker") || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("validateCounter(%+v) = %v, want an error mentioning %q", c.counter, err, c.want)
+		}
+	}
+	if err := validateCounter("Joker", nil); err != nil {
+		t.Errorf("expected jokers without a counter to be valid, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -51,6 +52,32 @@ func (s Suit) String() string {
 	}
 }
 
+// Name returns the suit's full name, e.g. "Hearts"
+func (s Suit) Name() string {
+	switch s {
+	case Hearts:
+		return "Hearts"
+	case Diamonds:
+		return "Diamonds"
+	case Clubs:
+		return "Clubs"
+	case Spades:
+		return "Spades"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseSuit converts a suit name such as "Hearts" into a Suit
+func ParseSuit(name string) (Suit, error) {
+	for s := Hearts; s <= Spades; s++ {
+		if strings.EqualFold(name, s.Name()) {
+			return s, nil
+		}
+	}
+	return Hearts, fmt.Errorf("unknown suit %q", name)
+}
+
 type Rank int
 
 const (
@@ -95,15 +122,88 @@ func (r Rank) Value() int {
 	}
 }
 
+// ParseRank converts a rank such as "A", "10" or "K" into a Rank
+func ParseRank(name string) (Rank, error) {
+	for r := Ace; r <= King; r++ {
+		if strings.EqualFold(name, r.String()) {
+			return r, nil
+		}
+	}
+	return Ace, fmt.Errorf("unknown rank %q", name)
+}
+
 type Card struct {
 	Suit Suit
 	Rank Rank
+	// Debuffed cards don't add their value to the score or trigger jokers.
+	// The flag is set on cards in hand for the current blind.
+	Debuffed bool
+	// Forced cards must be part of every hand played or discarded
+	Forced bool
+	// Gold cards pay GoldCardReward when held in hand as a blind is defeated
+	Gold bool
+	// Seal is the card's seal, if any
+	Seal Seal
 }
 
 func (c Card) String() string {
 	return fmt.Sprintf("%s%s", c.Rank, c.Suit)
 }
 
+// Card enhancements that card rules can check for
+const (
+	EnhancementGold = "Gold"
+)
+
+// enhancements lists every card enhancement
+var enhancements = []string{EnhancementGold}
+
+// Enhancement returns the card's enhancement, or an empty string for none
+func (c Card) Enhancement() string {
+	if c.Gold {
+		return EnhancementGold
+	}
+	return ""
+}
+
+// Seal is a seal on a card. Nothing hands seals out yet, but card rules can
+// already check for them.
+type Seal string
+
+const (
+	NoSeal     Seal = ""
+	GoldSeal   Seal = "Gold"
+	RedSeal    Seal = "Red"
+	BlueSeal   Seal = "Blue"
+	PurpleSeal Seal = "Purple"
+)
+
+// seals lists every seal a card can have
+var seals = []Seal{GoldSeal, RedSeal, BlueSeal, PurpleSeal}
+
+// ParseSeal converts a seal name such as "Red" into a Seal
+func ParseSeal(name string) (Seal, error) {
+	for _, s := range seals {
+		if strings.EqualFold(name, string(s)) {
+			return s, nil
+		}
+	}
+	return NoSeal, fmt.Errorf("unknown seal %q", name)
+}
+
+// isNumbered reports whether the card is a 2 through 10
+func isNumbered(card Card) bool {
+	return card.Rank >= Two && card.Rank <= Ten
+}
+
+// ScoringValue returns the chips the card adds when played
+func (c Card) ScoringValue() int {
+	if c.Debuffed {
+		return 0
+	}
+	return c.Rank.Value()
+}
+
 // NewDeck creates a standard 52-card deck
 func NewDeck() []Card {
 	var deck []Card
@@ -121,3 +221,21 @@ func ShuffleDeck(deck []Card) {
 		deck[i], deck[j] = deck[j], deck[i]
 	})
 }
+
+// CountBySuit returns how many of the given cards belong to each suit
+func CountBySuit(cards []Card) map[Suit]int {
+	counts := make(map[Suit]int)
+	for _, card := range cards {
+		counts[card.Suit]++
+	}
+	return counts
+}
+
+// CountByRank returns how many of the given cards have each rank
+func CountByRank(cards []Card) map[Rank]int {
+	counts := make(map[Rank]int)
+	for _, card := range cards {
+		counts[card.Rank]++
+	}
+	return counts
+}
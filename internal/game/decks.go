@@ -0,0 +1,148 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DeckComposition describes which cards a starting deck contains
+type DeckComposition string
+
+const (
+	// StandardComposition is the regular 52-card deck
+	StandardComposition DeckComposition = "Standard"
+	// NoFaceCards removes every Jack, Queen and King (40 cards)
+	NoFaceCards DeckComposition = "NoFaceCards"
+	// CheckeredComposition holds 26 Spades and 26 Hearts
+	CheckeredComposition DeckComposition = "Checkered"
+	// ErraticComposition holds 52 cards with random ranks and suits
+	ErraticComposition DeckComposition = "Erratic"
+)
+
+// DefaultDeckName is used when no starting deck is chosen
+const DefaultDeckName = "Standard Deck"
+
+// StartingDeck is a deck variant that alters the run's starting resources
+type StartingDeck struct {
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description"`
+	Hands       int             `yaml:"hands"`
+	Discards    int             `yaml:"discards"`
+	Money       int             `yaml:"money"`
+	Composition DeckComposition `yaml:"composition"`
+}
+
+// DecksYAML represents the root YAML structure of decks.yaml
+type DecksYAML struct {
+	Decks []StartingDeck `yaml:"decks"`
+}
+
+var startingDecks []StartingDeck
+
+// LoadDeckConfigs loads starting decks from YAML file with fallback to defaults
+func LoadDeckConfigs() error {
+	if err := loadDecksFromYAML(); err != nil {
+		fmt.Printf("Warning: Could not load decks.yaml, using defaults: %v\n", err)
+		setDefaultDecks()
+	}
+	return nil
+}
+
+// loadDecksFromYAML loads starting decks from YAML file
+func loadDecksFromYAML() error {
+	file, err := os.Open(filepath.Join("internal", "game", "decks.yaml"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	var decksYAML DecksYAML
+	if err := yaml.Unmarshal(data, &decksYAML); err != nil {
+		return err
+	}
+	if len(decksYAML.Decks) == 0 {
+		return fmt.Errorf("decks.yaml contains no decks")
+	}
+
+	for i, d := range decksYAML.Decks {
+		switch d.Composition {
+		case "":
+			decksYAML.Decks[i].Composition = StandardComposition
+		case StandardComposition, NoFaceCards, CheckeredComposition, ErraticComposition:
+		default:
+			return fmt.Errorf("deck %q has unknown composition %q", d.Name, d.Composition)
+		}
+	}
+
+	startingDecks = decksYAML.Decks
+	return nil
+}
+
+// setDefaultDecks sets hardcoded default starting decks
+func setDefaultDecks() {
+	startingDecks = []StartingDeck{
+		{Name: "Standard Deck", Description: "A regular 52-card deck", Composition: StandardComposition},
+		{Name: "Red Deck", Description: "+1 discard every round", Discards: 1, Composition: StandardComposition},
+		{Name: "Blue Deck", Description: "+1 hand every round", Hands: 1, Composition: StandardComposition},
+		{Name: "Yellow Deck", Description: "Start with an extra $10", Money: 10, Composition: StandardComposition},
+		{Name: "Abandoned Deck", Description: "Start run with no face cards in your deck", Composition: NoFaceCards},
+		{Name: "Checkered Deck", Description: "Start run with 26 Spades and 26 Hearts in deck", Composition: CheckeredComposition},
+		{Name: "Erratic Deck", Description: "All ranks and suits in deck are randomized", Composition: ErraticComposition},
+	}
+}
+
+// GetAvailableDecks returns all starting decks that can be chosen
+func GetAvailableDecks() []StartingDeck {
+	return startingDecks
+}
+
+// GetStartingDeck returns the starting deck with the given name if it exists
+func GetStartingDeck(name string) (StartingDeck, bool) {
+	for _, d := range startingDecks {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return StartingDeck{}, false
+}
+
+// BuildCards creates the unshuffled cards for this deck's composition
+func (d StartingDeck) BuildCards() []Card {
+	switch d.Composition {
+	case NoFaceCards:
+		var deck []Card
+		for _, card := range NewDeck() {
+			if card.Rank != Jack && card.Rank != Queen && card.Rank != King {
+				deck = append(deck, card)
+			}
+		}
+		return deck
+	case CheckeredComposition:
+		var deck []Card
+		for _, suit := range []Suit{Spades, Hearts} {
+			for i := 0; i < 2; i++ {
+				for rank := Ace; rank <= King; rank++ {
+					deck = append(deck, Card{Suit: suit, Rank: rank})
+				}
+			}
+		}
+		return deck
+	case ErraticComposition:
+		deck := make([]Card, 52)
+		for i := range deck {
+			deck[i] = Card{Suit: Suit(rng.Intn(4)), Rank: Rank(rng.Intn(13) + 1)}
+		}
+		return deck
+	default:
+		return NewDeck()
+	}
+}
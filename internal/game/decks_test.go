@@ -0,0 +1,58 @@
+package game
+
+import "testing"
+
+// TestStartingDeckCompositions verifies the cards built for each composition.
+func TestStartingDeckCompositions(t *testing.T) {
+	abandoned := StartingDeck{Composition: NoFaceCards}.BuildCards()
+	if len(abandoned) != 40 {
+		t.Fatalf("expected 40 cards in abandoned deck, got %d", len(abandoned))
+	}
+	for _, c := range abandoned {
+		if c.Rank == Jack || c.Rank == Queen || c.Rank == King {
+			t.Fatalf("abandoned deck should contain no face cards, found %v", c)
+		}
+	}
+
+	checkered := StartingDeck{Composition: CheckeredComposition}.BuildCards()
+	suits := CountBySuit(checkered)
+	if len(checkered) != 52 || suits[Spades] != 26 || suits[Hearts] != 26 {
+		t.Fatalf("expected 26 Spades and 26 Hearts, got %v", suits)
+	}
+
+	SetSeed(7)
+	erratic := StartingDeck{Composition: ErraticComposition}.BuildCards()
+	if len(erratic) != 52 {
+		t.Fatalf("expected 52 cards in erratic deck, got %d", len(erratic))
+	}
+	for _, c := range erratic {
+		if c.Rank < Ace || c.Rank > King || c.Suit < Hearts || c.Suit > Spades {
+			t.Fatalf("erratic deck produced invalid card %v", c)
+		}
+	}
+}
+
+// TestNewGameWithDeckModifiers verifies decks adjust hands, discards and money.
+func TestNewGameWithDeckModifiers(t *testing.T) {
+	red, err := NewGameWithConfig(&testEventHandler{}, RunConfig{Deck: "Red Deck"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if red.maxDiscards() != MaxDiscards+1 {
+		t.Fatalf("expected %d discards with Red Deck, got %d", MaxDiscards+1, red.maxDiscards())
+	}
+
+	blue, _ := NewGameWithConfig(&testEventHandler{}, RunConfig{Deck: "Blue Deck"})
+	if blue.maxHands() != MaxHands+1 {
+		t.Fatalf("expected %d hands with Blue Deck, got %d", MaxHands+1, blue.maxHands())
+	}
+
+	yellow, _ := NewGameWithConfig(&testEventHandler{}, RunConfig{Deck: "Yellow Deck"})
+	if yellow.money != StartingMoney+10 {
+		t.Fatalf("expected $%d with Yellow Deck, got $%d", StartingMoney+10, yellow.money)
+	}
+
+	if _, err := NewGameWithConfig(&testEventHandler{}, RunConfig{Deck: "Nonexistent Deck"}); err == nil {
+		t.Fatalf("expected error for unknown deck")
+	}
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// JokerEdition is a special finish a joker can roll with
+type JokerEdition string
+
+const (
+	NoEdition JokerEdition = ""
+	// NegativeEdition jokers don't take up a joker slot
+	NegativeEdition JokerEdition = "Negative"
+)
+
+// NegativePrice is added to the price of a Negative joker
+const NegativePrice = 5
+
+// Negative reports whether the joker is Negative
+func (j OwnedJoker) Negative() bool {
+	return j.Edition == NegativeEdition
+}
+
+// applyEdition gives a joker rolled for the shop or a pack a 1 in
+// negative_odds chance of being Negative, rolled with r
+func applyEdition(r *rand.Rand, joker OwnedJoker) OwnedJoker {
+	odds := GetShopConfig().NegativeOdds
+	if joker.Name == "" || odds <= 0 || r.Intn(odds) != 0 {
+		return joker
+	}
+	joker.Edition = NegativeEdition
+	joker.PurchasePrice += NegativePrice
+	return joker
+}
+
+// jokerSlots returns how many jokers the player can hold, not counting
+// Negative ones
+func (g *Game) jokerSlots() int {
+	return GetJokerSlots() + g.voucherBonus(VoucherJokerSlots)
+}
+
+// JokerSlotsUsed returns how many joker slots the jokers take up. Negative
+// jokers don't take a slot.
+func JokerSlotsUsed(jokers []OwnedJoker) int {
+	used := 0
+	for _, joker := range jokers {
+		if !joker.Negative() {
+			used++
+		}
+	}
+	return used
+}
+
+// jokerSlotsUsed returns how many of the player's joker slots are taken
+func (g *Game) jokerSlotsUsed() int {
+	return JokerSlotsUsed(g.jokers)
+}
+
+// hasJokerRoom reports whether the player has a free slot for the joker
+func (g *Game) hasJokerRoom(joker OwnedJoker) bool {
+	return joker.Negative() || g.jokerSlotsUsed() < g.jokerSlots()
+}
+
+// noJokerRoomReason explains why a joker can't be added
+func (g *Game) noJokerRoomReason(name string) string {
+	return fmt.Sprintf("No room for %s! Joker slots are full (%d/%d). Sell a joker to make room.", name, g.jokerSlotsUsed(), g.jokerSlots())
+}
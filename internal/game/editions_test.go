@@ -0,0 +1,128 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// fillJokerSlots gives the player as many jokers as they have slots
+func fillJokerSlots(g *Game) {
+	g.jokers = nil
+	for i := 0; i < g.jokerSlots(); i++ {
+		g.jokers = append(g.jokers, OwnedJoker{Joker: Joker{Name: "Filler", Price: 4}, PurchasePrice: 4})
+	}
+}
+
+// TestShopBuyNeedsJokerSlot verifies jokers can't be bought with every slot
+// taken, unless they're Negative.
+func TestShopBuyNeedsJokerSlot(t *testing.T) {
+	g := createTestGame([]string{})
+	fillJokerSlots(g)
+	shop := &Shop{game: g, Items: []ShopItem{
+		{Joker: OwnedJoker{Joker: Joker{Name: "J1", Price: 5}, PurchasePrice: 5}},
+		{Joker: OwnedJoker{Joker: Joker{Name: "J2", Price: 5}, PurchasePrice: 5, Edition: NegativeEdition}},
+	}}
+
+	invalid, ok := shop.Buy(1)[0].(InvalidActionEvent)
+	if !ok {
+		t.Fatalf("expected buying a joker with full slots to fail")
+	}
+	want := "Joker slots are full (5/5)"
+	if !strings.Contains(invalid.Reason, want) {
+		t.Errorf("reason = %q, want it to contain %q", invalid.Reason, want)
+	}
+	if g.money != 20 || shop.Items[0].Empty() {
+		t.Fatalf("expected a failed purchase to cost nothing, money=%d", g.money)
+	}
+
+	if _, ok := shop.Buy(2)[0].(ShopItemPurchasedEvent); !ok {
+		t.Fatalf("expected a Negative joker to be bought with full slots")
+	}
+	if g.jokerSlotsUsed() != g.jokerSlots() || len(g.jokers) != g.jokerSlots()+1 {
+		t.Fatalf("expected the Negative joker not to take a slot, jokers=%d", len(g.jokers))
+	}
+
+	g.jokers = g.jokers[1:]
+	if _, ok := shop.Buy(1)[0].(ShopItemPurchasedEvent); !ok {
+		t.Fatalf("expected selling a joker to make room for J1")
+	}
+}
+
+// TestAntimatterAddsJokerSlot verifies the Antimatter voucher raises the cap.
+func TestAntimatterAddsJokerSlot(t *testing.T) {
+	g := createTestGame([]string{})
+	if g.jokerSlots() != DefaultJokerSlots {
+		t.Fatalf("jokerSlots() = %d, want %d", g.jokerSlots(), DefaultJokerSlots)
+	}
+	antimatter, _ := GetVoucherByName("Antimatter")
+	g.vouchers = []Voucher{antimatter}
+	if g.jokerSlots() != DefaultJokerSlots+1 {
+		t.Fatalf("jokerSlots() = %d, want %d", g.jokerSlots(), DefaultJokerSlots+1)
+	}
+	if g.gameStateEvent().JokerSlots != DefaultJokerSlots+1 {
+		t.Fatalf("expected the game state to report %d joker slots", DefaultJokerSlots+1)
+	}
+}
+
+// TestFullJokerSlotsBlockCreatedJokers verifies Buffoon Pack picks and
+// joker-making consumables wait for a free slot.
+func TestFullJokerSlotsBlockCreatedJokers(t *testing.T) {
+	setDefaultJokerConfigs()
+	g := createTestGame([]string{})
+	fillJokerSlots(g)
+	judgement, _ := GetConsumableByName("Judgement")
+	shop := &Shop{game: g, Pack: &OpenPack{
+		Pack:      BoosterPack{Type: BuffoonPack, Size: NormalPack, Cards: 2, Picks: 1},
+		Cards:     []PackCard{{Joker: OwnedJoker{Joker: Joker{Name: "J1"}}}, {Consumable: judgement}},
+		PicksLeft: 1,
+	}}
+
+	for card := 1; card <= 2; card++ {
+		if _, ok := shop.Pick(card)[0].(InvalidActionEvent); !ok {
+			t.Errorf("expected picking card %d with full slots to fail", card)
+		}
+	}
+	if shop.Pack.PicksLeft != 1 || shop.Pack.Cards[0].Empty() {
+		t.Fatalf("expected failed picks to leave the pack alone")
+	}
+
+	g.consumables = []Consumable{judgement}
+	g.handleUseConsumableAction([]string{"1"})
+	if len(g.consumables) != 1 || len(g.jokers) != g.jokerSlots() {
+		t.Fatalf("expected Judgement to stay unused with full slots, consumables=%v", g.consumables)
+	}
+}
+
+// TestApplyEditionOdds verifies negative_odds controls Negative jokers and
+// that the roll follows the seed.
+func TestApplyEditionOdds(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	withShopConfig(t, ShopConfig{Slots: 2, NegativeOdds: 1})
+	joker := applyEdition(r, OwnedJoker{Joker: Joker{Name: "J1", Price: 5}, PurchasePrice: 5})
+	if !joker.Negative() || joker.PurchasePrice != 5+NegativePrice {
+		t.Fatalf("expected a Negative joker costing $%d, got %+v", 5+NegativePrice, joker)
+	}
+
+	withShopConfig(t, ShopConfig{Slots: 2})
+	if applyEdition(r, OwnedJoker{Joker: Joker{Name: "J1", Price: 5}, PurchasePrice: 5}).Negative() {
+		t.Fatalf("expected no Negative jokers when negative_odds is 0")
+	}
+
+	withShopConfig(t, ShopConfig{Slots: 2, NegativeOdds: 3})
+	roll := func(seed int64) string {
+		r := rand.New(rand.NewSource(seed))
+		editions := ""
+		for i := 0; i < 20; i++ {
+			if applyEdition(r, OwnedJoker{Joker: Joker{Name: "J1"}}).Negative() {
+				editions += "N"
+			} else {
+				editions += "-"
+			}
+		}
+		return editions
+	}
+	if first, second := roll(7), roll(7); first != second {
+		t.Fatalf("expected the same editions for the same seed, got %s and %s", first, second)
+	}
+}
@@ -0,0 +1,260 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// stepNames lists each step as joker:effect for comparing traces
+func stepNames(steps []ScoringStep) string {
+	var names []string
+	for _, step := range steps {
+		names = append(names, step.Joker+":"+string(step.Effect))
+	}
+	return strings.Join(names, " ")
+}
+
+// TestMultiplyChipsOrder verifies ×Chips applies to the chips built up so
+// far, and to all of them under legacy scoring.
+func TestMultiplyChipsOrder(t *testing.T) {
+	plus := ScoringStep{Joker: "Plus", Effect: AddChips, Amount: 30}
+	times := ScoringStep{Joker: "Stone Mason", Effect: MultiplyChips, Amount: 2}
+
+	if chips, _ := scoreHand(10, 4, []ScoringStep{plus, times}, false); chips != 80 {
+		t.Errorf("+30 then ×2: chips = %s, want 80", chips)
+	}
+	if chips, _ := scoreHand(10, 4, []ScoringStep{times, plus}, false); chips != 50 {
+		t.Errorf("×2 then +30: chips = %s, want 50", chips)
+	}
+	if chips, _ := scoreHand(10, 4, []ScoringStep{times, plus}, true); chips != 80 {
+		t.Errorf("legacy ×2 then +30: chips = %s, want 80", chips)
+	}
+
+	LoadConfig()
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		deckIndex:    InitialCards,
+		playerCards:  []Card{{Rank: Two, Suit: Hearts}, {Rank: Three, Suit: Clubs}},
+		handLevels:   map[string]int{},
+		jokers:       []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Stone Mason", Effect: MultiplyChips, EffectMagnitude: 2}))},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+	g.handlePlayAction([]string{"1"})
+	for _, e := range handler.events {
+		if hp, ok := e.(HandPlayedEvent); ok {
+			if want := Score(hp.BaseScore+hp.CardValues) * 2; hp.FinalChips != want || hp.FinalScore != want*hp.FinalMult {
+				t.Errorf("played hand: %s chips scoring %s, want %s chips", hp.FinalChips, hp.FinalScore, want)
+			}
+			return
+		}
+	}
+	t.Fatal("no hand played")
+}
+
+// TestAddMoneyPerCard verifies money is paid for each matching card played
+// or discarded, and only once per card.
+func TestAddMoneyPerCard(t *testing.T) {
+	business := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Business Card", Effect: AddMoneyPerCard, EffectMagnitude: 1, CardCondition: &CardCondition{Face: boolPtr(true)}}))
+	played := []Card{{Rank: King, Suit: Hearts}, {Rank: Two, Suit: Clubs}, {Rank: Jack, Suit: Spades}}
+
+	steps := JokerScoringSteps([]OwnedJoker{business}, ConditionState{HandType: "High Card"}, played, nil)
+	if lines := moneyLines(steps); sumRewards(lines) != 2 || len(lines) != 1 {
+		t.Errorf("played: got %+v, want one $2 line", lines)
+	}
+	if len(steps) != 2 || steps[0].Card.Rank != King || steps[1].Card.Rank != Jack {
+		t.Errorf("expected a step for the King and the Jack, got %+v", steps)
+	}
+
+	g := createTestGame([]string{})
+	g.playerCards = played
+	g.jokers = []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Business Card", Effect: AddMoneyPerCard, EffectMagnitude: 1, Trigger: TriggerDiscard, CardCondition: &CardCondition{Face: boolPtr(true)}}))}
+	money := g.money
+	g.handleDiscardAction([]string{"1", "2", "3"})
+	if g.money != money+2 {
+		t.Errorf("discard: money went from %d to %d, want +$2", money, g.money)
+	}
+}
+
+// TestRetriggerCard verifies a card fires again as many times as the
+// retrigger's magnitude, straight after itself, and that ReplayCard still
+// replays once.
+func TestRetriggerCard(t *testing.T) {
+	jokers := []OwnedJoker{
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Aces", Effect: AddChips, EffectMagnitude: 10, CardMatchingRule: CardIsAce})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Hack", Effect: RetriggerCard, EffectMagnitude: 2, CardCondition: &CardCondition{Ranks: []string{"A"}}})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Plus", Effect: AddMult, EffectMagnitude: 4})),
+	}
+	played := []Card{{Rank: Ace, Suit: Spades}, {Rank: Two, Suit: Hearts}}
+
+	steps := JokerScoringSteps(jokers, ConditionState{HandType: "High Card"}, played, nil)
+	want := "Aces:AddChips Hack:RetriggerCard Aces:AddChips Aces:AddChips Plus:AddMult"
+	if got := stepNames(steps); got != want {
+		t.Fatalf("steps = %s, want %s", got, want)
+	}
+	if steps[1].Amount != 2 || steps[1].Card == nil || steps[1].Card.Rank != Ace {
+		t.Errorf("expected the retrigger to fire the Ace twice more, got %+v", steps[1])
+	}
+
+	jokers[1] = newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Dusk", Effect: ReplayCard, EffectMagnitude: 5, CardMatchingRule: CardIsAce}))
+	steps = JokerScoringSteps(jokers, ConditionState{HandType: "High Card"}, played, nil)
+	if got := stepNames(steps); got != "Aces:AddChips Dusk:ReplayCard Aces:AddChips Plus:AddMult" {
+		t.Errorf("ReplayCard steps = %s, want a single replay", got)
+	}
+}
+
+// TestCopyJokers verifies Blueprint copies the joker to its right and
+// Brainstorm the leftmost one, at that joker's magnitude, and that copies
+// of copies end.
+func TestCopyJokers(t *testing.T) {
+	blueprint := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Blueprint", Effect: CopyJokerRight}))
+	brainstorm := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Brainstorm", Effect: CopyJokerLeftmost}))
+	plus := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Plus", Effect: AddMult, EffectMagnitude: 4}))
+	times := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Times", Effect: MultiplyMult, EffectMagnitude: 3}))
+	egg := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Egg", Effect: GainSellValue, EffectMagnitude: 3}))
+	state := ConditionState{HandType: "High Card"}
+	played := []Card{{Rank: Two, Suit: Hearts}}
+
+	cases := []struct {
+		name   string
+		jokers []OwnedJoker
+		want   string
+	}{
+		{"blueprint copies right", []OwnedJoker{blueprint, times, plus}, "Blueprint:MultiplyMult Times:MultiplyMult Plus:AddMult"},
+		{"blueprint at the end", []OwnedJoker{plus, blueprint}, "Plus:AddMult"},
+		{"brainstorm copies leftmost", []OwnedJoker{plus, times, brainstorm}, "Plus:AddMult Times:MultiplyMult Brainstorm:AddMult"},
+		{"blueprint copies brainstorm", []OwnedJoker{plus, blueprint, brainstorm}, "Plus:AddMult Blueprint:AddMult Brainstorm:AddMult"},
+		{"copies of copies end", []OwnedJoker{brainstorm, blueprint}, ""},
+		{"sell value isn't copied", []OwnedJoker{blueprint, egg}, ""},
+	}
+	for _, c := range cases {
+		if got := stepNames(JokerScoringSteps(c.jokers, state, played, nil)); got != c.want {
+			t.Errorf("%s: steps = %q, want %q", c.name, got, c.want)
+		}
+	}
+
+	debuffed := times
+	debuffed.Debuffed = true
+	if got := stepNames(JokerScoringSteps([]OwnedJoker{blueprint, debuffed}, state, played, nil)); got != "" {
+		t.Errorf("expected nothing to copy from a debuffed joker, got %q", got)
+	}
+	steps := JokerScoringSteps([]OwnedJoker{blueprint, times}, state, played, nil)
+	if steps[0].Amount != 3 || steps[0].JokerIndex != 0 {
+		t.Errorf("expected Blueprint to fire ×3 as joker 0, got %+v", steps[0])
+	}
+}
+
+// TestCreateConsumable verifies jokers create consumables while there's
+// room for them.
+func TestCreateConsumable(t *testing.T) {
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Cartomancer", Effect: CreateConsumable, Consumable: "Tarot"}))}
+	for i := 0; i <= DefaultConsumableSlots; i++ {
+		g.fireJokers(TriggerBlindSelect, ConditionState{}, nil)
+	}
+	if len(g.consumables) != DefaultConsumableSlots {
+		t.Fatalf("expected %d consumables after filling every slot, got %d", DefaultConsumableSlots, len(g.consumables))
+	}
+	for _, c := range g.consumables {
+		if c.Type != TarotCard {
+			t.Errorf("expected a Tarot card, got %s", c.Name)
+		}
+	}
+
+	g.consumables = nil
+	g.jokers = []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Astronomer", Effect: CreateConsumable, Consumable: "Mars", Trigger: TriggerHandPlayed}))}
+	g.fireJokers(TriggerHandPlayed, ConditionState{HandType: "Pair"}, nil)
+	if len(g.consumables) != 1 || g.consumables[0].Name != "Mars" {
+		t.Errorf("expected Mars, got %v", g.consumables)
+	}
+
+	SetSeed(5)
+	create := func() []Consumable {
+		g := createTestGame([]string{})
+		g.jokers = []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Cartomancer", Effect: CreateConsumable, EffectMagnitude: 2, Consumable: "Tarot"}))}
+		g.fireJokers(TriggerBlindSelect, ConditionState{}, nil)
+		return g.consumables
+	}
+	if first, second := create(), create(); len(first) != 2 || first[0] != second[0] || first[1] != second[1] {
+		t.Errorf("expected the same Tarots for the same seed, got %v and %v", first, second)
+	}
+}
+
+// TestGainSellValueOnHandPlayed verifies sell value can grow as hands are
+// played, on the joker that fired.
+func TestGainSellValueOnHandPlayed(t *testing.T) {
+	LoadConfig()
+	g := &Game{
+		deck:         NewDeck(),
+		deckIndex:    InitialCards,
+		playerCards:  []Card{{Rank: Two, Suit: Hearts}, {Rank: Three, Suit: Clubs}},
+		handLevels:   map[string]int{},
+		eventEmitter: NewEventEmitter(),
+		jokers: []OwnedJoker{
+			newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Plus", Effect: AddMult, EffectMagnitude: 4})),
+			newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Piggy", Effect: GainSellValue, EffectMagnitude: 1, Trigger: TriggerHandPlayed})),
+		},
+	}
+	g.handlePlayAction([]string{"1"})
+	if g.jokers[0].SellBonus != 0 || g.jokers[1].SellBonus != 1 {
+		t.Errorf("sell bonuses = %d and %d, want 0 and 1", g.jokers[0].SellBonus, g.jokers[1].SellBonus)
+	}
+}
+
+// TestDestroyCard verifies destroyed cards leave the deck for the rest of
+// the run.
+func TestDestroyCard(t *testing.T) {
+	g := createTestGame([]string{})
+	g.deck = NewDeck()
+	g.deckIndex = 3
+	g.playerCards = []Card{g.deck[0], g.deck[1], g.deck[2]}
+	g.jokers = []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Trading Card", Effect: DestroyCard, Trigger: TriggerDiscard, CardCondition: &CardCondition{Face: boolPtr(false)}}))}
+	discarded := g.playerCards[:2]
+	size := len(g.deck)
+
+	g.handleDiscardAction([]string{"1", "2"})
+	if len(g.deck) != size-2 || len(g.destroyedCards) != 2 {
+		t.Fatalf("deck has %d cards with %d destroyed, want %d with 2", len(g.deck), len(g.destroyedCards), size-2)
+	}
+	for _, c := range g.deck {
+		if c == discarded[0] || c == discarded[1] {
+			t.Errorf("expected %s to be gone from the deck", c)
+		}
+	}
+	g.startBlind()
+	if len(g.deck) != size-2 {
+		t.Errorf("expected the deck to stay at %d cards for the next blind, got %d", size-2, len(g.deck))
+	}
+
+	retriggered := []OwnedJoker{
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Sixth Sense", Effect: DestroyCard, Trigger: TriggerCardScored, CardMatchingRule: CardIsAce})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Hack", Effect: RetriggerCard, EffectMagnitude: 1, CardMatchingRule: CardIsAce})),
+	}
+	steps := JokerScoringSteps(retriggered, ConditionState{HandType: "High Card"}, []Card{{Rank: Ace, Suit: Spades}}, nil)
+	if got := stepNames(steps); got != "Sixth Sense:DestroyCard Hack:RetriggerCard" {
+		t.Errorf("steps = %q, want the Ace destroyed only once", got)
+	}
+}
+
+// TestValidateEffects verifies bad effects and their parameters are
+// reported with the joker's name.
+func TestValidateEffects(t *testing.T) {
+	cases := []struct {
+		config JokerConfig
+		want   string
+	}{
+		{JokerConfig{Name: "Mystery", Effect: "AddLuck"}, `unknown effect "AddLuck"`},
+		{JokerConfig{Name: "Hack", Effect: RetriggerCard, CardMatchingRule: CardIsFace}, "needs a magnitude of at least 1"},
+		{JokerConfig{Name: "Cartomancer", Effect: CreateConsumable, Consumable: "Joker"}, `unknown consumable "Joker"`},
+		{JokerConfig{Name: "Plus", Effect: AddMult, Consumable: "Tarot"}, "only CreateConsumable makes consumables"},
+		{JokerConfig{Name: "Trading Card", Effect: DestroyCard, Trigger: TriggerHandPlayed}, `can't trigger on "hand_played"`},
+		{JokerConfig{Name: "Gems", Effects: []JokerEffectConfig{{Effect: AddMult}, {Effect: RetriggerCard}}}, "effects[1].effect_magnitude"},
+	}
+	for _, c := range cases {
+		err := validateJokerConfig(c.config)
+		if err == nil || !strings.Contains(err.Error(), "joker "+c.config.Name) || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("validateJokerConfig(%s) = %v, want an error mentioning %q", c.config.Name, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// endlessTestHandler accepts the endless mode offer.
+type endlessTestHandler struct {
+	testEventHandler
+}
+
+func (h *endlessTestHandler) GetEndlessAction() (PlayerAction, []string, bool) {
+	return PlayerActionContinueEndless, nil, false
+}
+
+// TestEndlessAnteRequirements verifies targets past the table keep growing and saturate.
+func TestEndlessAnteRequirements(t *testing.T) {
+	LoadConfig()
+
+	last := GetAnteRequirement(MaxAntes, BossBlind)
+	ante9 := GetAnteRequirement(MaxAntes+1, BossBlind)
+	ante10 := GetAnteRequirement(MaxAntes+2, BossBlind)
+	if want := int(float64(last) * DefaultEndlessGrowth); ante9 != want {
+		t.Fatalf("ante 9 boss target = %d, want %d", ante9, want)
+	}
+	if ante10 <= ante9 || ante10-ante9 <= ante9-last {
+		t.Fatalf("expected accelerating growth, got %d, %d, %d", last, ante9, ante10)
+	}
+	if got := GetAnteRequirement(200, BossBlind); got != math.MaxInt {
+		t.Fatalf("expected target to saturate at max int, got %d", got)
+	}
+	if got := PurpleStake.ScaleTarget(200, math.MaxInt); got != math.MaxInt {
+		t.Fatalf("expected stake scaling to saturate, got %d", got)
+	}
+}
+
+// TestOfferEndlessMode verifies accepting endless mode moves on to the next ante.
+func TestOfferEndlessMode(t *testing.T) {
+	LoadConfig()
+	handler := &endlessTestHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		currentAnte:  MaxAntes + 1,
+		currentBlind: SmallBlind,
+		totalScore:   5000,
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	if !g.offerEndlessMode() {
+		t.Fatalf("expected endless mode to be accepted")
+	}
+	if !g.endless {
+		t.Fatalf("expected game to be in endless mode")
+	}
+	if g.totalScore != 0 {
+		t.Fatalf("expected score reset for the next blind, got %d", g.totalScore)
+	}
+	if g.phase != PhaseBlindSelect {
+		t.Fatalf("expected the next blind to be chosen after the shop, got %s", g.phase)
+	}
+	if g.highestAnteReached() != MaxAntes+1 {
+		t.Fatalf("highest ante = %d, want %d", g.highestAnteReached(), MaxAntes+1)
+	}
+
+	declined := &Game{currentAnte: MaxAntes + 1, eventEmitter: NewEventEmitter()}
+	declined.eventEmitter.SetEventHandler(&testEventHandler{})
+	if declined.offerEndlessMode() || declined.endless {
+		t.Fatalf("expected quitting to decline endless mode")
+	}
+	if declined.highestAnteReached() != MaxAntes {
+		t.Fatalf("highest ante = %d, want %d", declined.highestAnteReached(), MaxAntes)
+	}
+}
+
+// TestRecordHighestAnte verifies the highest ante record only moves up.
+func TestRecordHighestAnte(t *testing.T) {
+	oldPath := statsPath
+	statsPath = filepath.Join(t.TempDir(), "stats.json")
+	defer func() { statsPath = oldPath }()
+
+	if prev, err := RecordHighestAnte(5); err != nil || prev != 0 {
+		t.Fatalf("RecordHighestAnte(5) = %d, %v; want 0, nil", prev, err)
+	}
+	// Replace the file with the same record in different formatting, so a
+	// rewrite would show
+	if err := os.WriteFile(statsPath, []byte(`{"highest_ante":5}`), 0644); err != nil {
+		t.Fatalf("writing stats: %v", err)
+	}
+	for _, ante := range []int{3, 5} {
+		if prev, err := RecordHighestAnte(ante); err != nil || prev != 5 {
+			t.Fatalf("RecordHighestAnte(%d) = %d, %v; want 5, nil", ante, prev, err)
+		}
+	}
+	if data, _ := os.ReadFile(statsPath); string(data) != `{"highest_ante":5}` {
+		t.Fatalf("expected stats not to be rewritten without a new record, got %s", data)
+	}
+	if prev, err := RecordHighestAnte(11); err != nil || prev != 5 {
+		t.Fatalf("RecordHighestAnte(11) = %d, %v; want 5, nil", prev, err)
+	}
+	stats, err := LoadStats()
+	if err != nil || stats.HighestAnte != 11 {
+		t.Fatalf("LoadStats() = %+v, %v; want highest ante 11", stats, err)
+	}
+}
+
+// quitAtCashOutHandler quits at the cash-out screen and accepts endless mode.
+type quitAtCashOutHandler struct {
+	endlessTestHandler
+}
+
+func (h *quitAtCashOutHandler) GetCashOutAction() (PlayerAction, []string, bool) {
+	return PlayerActionNone, nil, true
+}
+
+// TestQuitAtFinalCashOut verifies quitting at the cash-out after the final
+// boss still wins the run and offers endless mode, and that a save left at
+// that point offers it again when loaded.
+func TestQuitAtFinalCashOut(t *testing.T) {
+	LoadConfig()
+	oldPath := statsPath
+	statsPath = filepath.Join(t.TempDir(), "stats.json")
+	defer func() { statsPath = oldPath }()
+	defer os.RemoveAll("saves")
+
+	handler := &quitAtCashOutHandler{}
+	g := NewGame(handler)
+	g.currentAnte = MaxAntes
+	g.currentBlind = BossBlind
+	g.phase = PhasePlaying
+	g.currentTarget = g.blindTarget(MaxAntes, BossBlind)
+	g.totalScore = g.currentTarget
+	g.Run()
+
+	if !hasEvent[VictoryEvent](handler.events) {
+		t.Fatal("expected quitting at the final cash-out to still win the run")
+	}
+	if !g.endless || g.currentAnte != MaxAntes+1 {
+		t.Fatalf("expected endless mode at ante %d, got endless=%v at ante %d", MaxAntes+1, g.endless, g.currentAnte)
+	}
+
+	won := &Game{currentAnte: MaxAntes + 1, phase: PhaseBlindSelect, eventEmitter: NewEventEmitter()}
+	declined := &testEventHandler{}
+	won.eventEmitter.SetEventHandler(declined)
+	won.Run()
+	if !hasEvent[VictoryEvent](declined.events) {
+		t.Error("expected a run loaded past the final ante to be won")
+	}
+}
+
+// hasEvent reports whether any of the events has type E
+func hasEvent[E Event](events []Event) bool {
+	for _, e := range events {
+		if _, ok := e.(E); ok {
+			return true
+		}
+	}
+	return false
+}
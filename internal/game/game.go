@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,7 @@ const (
 	BossBlindReward     = 6
 	UnusedHandReward    = 1
 	UnusedDiscardReward = 1
+	GoldCardReward      = 3
 )
 
 // BlindType represents the type of blind being played
@@ -48,6 +50,26 @@ func (bt BlindType) String() string {
 	}
 }
 
+// GamePhase is the part of a blind the game is in, kept in saves so a run
+// resumes where it left off
+type GamePhase int
+
+const (
+	// PhasePlaying is when hands are being played against a blind
+	PhasePlaying GamePhase = iota
+	// PhaseBlindSelect is when the next blind is being chosen
+	PhaseBlindSelect
+)
+
+func (p GamePhase) String() string {
+	switch p {
+	case PhaseBlindSelect:
+		return "blind_select"
+	default:
+		return "playing"
+	}
+}
+
 // Blind represents a single blind challenge
 type Blind struct {
 	Type        BlindType
@@ -66,23 +88,34 @@ const (
 
 // Game represents the current game state
 type Game struct {
-	totalScore        int
+	totalScore        Score
 	handsPlayed       int
 	discardsUsed      int
 	deck              []Card
 	deckIndex         int
+	addedCards        []Card // cards added to the deck during the run, e.g. from packs
+	destroyedCards    []Card // cards jokers have removed from the deck for good
 	playerCards       []Card
 	displayToOriginal []int // maps display position (0-based) to original position
 	sortMode          SortMode
 	currentAnte       int
 	currentBlind      BlindType
-	currentTarget     int
+	currentTarget     Score
 	currentBoss       Boss
-	currentBossRule   BossRule
+	handTypesPlayed   []string // hand types played this blind, for boss rules
+	bossDisabled      bool     // set when the player switches off the current boss
 	money             int
-	jokers            []Joker
+	jokers            []OwnedJoker
 	handLevels        map[string]int
-	rerollCost        int
+	startingDeck      StartingDeck
+	stake             Stake
+	endless           bool
+	phase             GamePhase
+	vouchers          []Voucher
+	voucherAnte       int // ante whose voucher has been bought
+	consumables       []Consumable
+	tags              []Tag
+	shopRand          *rand.Rand // rolls the current shop, its packs and the cards jokers and consumables create
 	eventEmitter      *SimpleEventEmitter
 }
 
@@ -90,27 +123,64 @@ type Game struct {
 func (g *Game) handSize() int {
 	size := InitialCards
 	for _, j := range g.jokers {
+		if !j.Active() {
+			continue
+		}
 		for _, eff := range j.Effects {
 			if eff.Effect == AddHandSize {
 				size += eff.EffectMagnitude
 			}
 		}
 	}
-	if g.currentBlind == BossBlind {
-		switch g.currentBossRule {
-		case BossRuleMinusHand:
-			size--
-		case BossRulePlusHand:
-			size++
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == ChangeHandSize {
+			size += eff.Magnitude
 		}
 	}
 	return size
 }
 
-// maxDiscards returns allowed discards based on jokers
+// bossEffects returns the current boss's effects, or nil outside Boss Blinds
+// and once the boss has been disabled
+func (g *Game) bossEffects() []BossEffectConfig {
+	if g.currentBlind != BossBlind || g.bossDisabled {
+		return nil
+	}
+	return g.currentBoss.Effects
+}
+
+// bossHasEffect reports whether the current boss has the given effect
+func (g *Game) bossHasEffect(effect BossEffect) bool {
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
+
+// maxHands returns allowed hands per blind based on the starting deck and boss
+func (g *Game) maxHands() int {
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == SetHands {
+			return eff.Magnitude
+		}
+	}
+	return MaxHands + g.startingDeck.Hands + g.voucherBonus(VoucherHands)
+}
+
+// maxDiscards returns allowed discards based on jokers, the starting deck, stake and boss
 func (g *Game) maxDiscards() int {
-	max := MaxDiscards
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == SetDiscards {
+			return eff.Magnitude
+		}
+	}
+	max := MaxDiscards + g.startingDeck.Discards + g.stake.DiscardModifier() + g.voucherBonus(VoucherDiscards)
 	for _, j := range g.jokers {
+		if !j.Active() {
+			continue
+		}
 		for _, eff := range j.Effects {
 			if eff.Effect == AddDiscards {
 				max += eff.EffectMagnitude
@@ -120,6 +190,11 @@ func (g *Game) maxDiscards() int {
 	return max
 }
 
+// blindTarget returns the score required for a blind, scaled by the stake
+func (g *Game) blindTarget(ante int, blind BlindType) Score {
+	return Score(g.stake.ScaleTarget(ante, GetAnteRequirement(ante, blind)))
+}
+
 // LevelUpHand increases the level of the specified hand type if possible
 func (g *Game) LevelUpHand(handName string) {
 	if g.handLevels == nil {
@@ -136,20 +211,105 @@ func (g *Game) LevelUpHand(handName string) {
 	}
 }
 
-// applyBossCardModifiers adjusts card values based on current boss rule
-func (g *Game) applyBossCardModifiers(cards []Card, cardValues int) int {
-	if g.currentBlind != BossBlind {
-		return cardValues
+// isCardDebuffed reports whether the current boss debuffs a card. Jokers
+// that change suits or face cards change what the boss debuffs too.
+func (g *Game) isCardDebuffed(card Card) bool {
+	m := cardModifiers(g.jokers)
+	for _, eff := range g.bossEffects() {
+		switch eff.Effect {
+		case DebuffSuit:
+			if suit, err := ParseSuit(eff.Suit); err == nil && m.hasSuit(card, suit) {
+				return true
+			}
+		case DebuffFaceCards:
+			if m.isFace(card) {
+				return true
+			}
+		case DebuffAllCards:
+			return true
+		}
+	}
+	return false
+}
+
+// applyDebuffs refreshes the debuff flag on every card in hand
+func (g *Game) applyDebuffs() {
+	for i := range g.playerCards {
+		g.playerCards[i].Debuffed = g.isCardDebuffed(g.playerCards[i])
+	}
+}
+
+// applyForcedSelection picks the card in hand that must be selected, for
+// bosses that force one
+func (g *Game) applyForcedSelection() {
+	for i := range g.playerCards {
+		g.playerCards[i].Forced = false
+	}
+	if g.bossHasEffect(ForceCardSelection) && len(g.playerCards) > 0 {
+		g.playerCards[rng.Intn(len(g.playerCards))].Forced = true
 	}
-	switch g.currentBossRule {
-	case BossRuleNoHearts:
-		for _, c := range cards {
-			if c.Suit == Hearts {
-				cardValues -= c.Rank.Value()
+}
+
+// withForcedCard adds the forced card in hand to a selection if it is missing
+func (g *Game) withForcedCard(cards []Card, indices []int) ([]Card, []int) {
+	for i, card := range g.playerCards {
+		if !card.Forced {
+			continue
+		}
+		for _, idx := range indices {
+			if idx == i {
+				return cards, indices
 			}
 		}
+		return append(cards, card), append(indices, i)
+	}
+	return cards, indices
+}
+
+// applyBossJokerEffects flips, shuffles and disables jokers for bosses that
+// do so once play begins
+func (g *Game) applyBossJokerEffects() {
+	if g.bossHasEffect(FlipJokers) && len(g.jokers) > 0 {
+		rng.Shuffle(len(g.jokers), func(i, j int) { g.jokers[i], g.jokers[j] = g.jokers[j], g.jokers[i] })
+		for i := range g.jokers {
+			g.jokers[i].FaceDown = true
+		}
+		g.eventEmitter.EmitWarning(fmt.Sprintf("%s flips and shuffles your jokers", g.currentBoss.Name))
+	}
+	g.disableRandomJoker()
+}
+
+// clearBossJokerEffects turns jokers face up and re-enables them
+func (g *Game) clearBossJokerEffects() {
+	for i := range g.jokers {
+		g.jokers[i].Disabled = false
+		g.jokers[i].FaceDown = false
+	}
+}
+
+// disableRandomJoker disables a random joker for the next hand, for bosses
+// that do so
+func (g *Game) disableRandomJoker() {
+	for i := range g.jokers {
+		g.jokers[i].Disabled = false
+	}
+	if !g.bossHasEffect(DisableRandomJoker) || len(g.jokers) == 0 {
+		return
 	}
-	return cardValues
+	i := rng.Intn(len(g.jokers))
+	g.jokers[i].Disabled = true
+	name, _ := g.jokers[i].Face()
+	g.eventEmitter.EmitWarning(fmt.Sprintf("%s disables %s for this hand", g.currentBoss.Name, name))
+}
+
+// disableBossOnJokerSold switches off bosses that last until a joker is sold
+func (g *Game) disableBossOnJokerSold() {
+	if !g.bossHasEffect(DebuffAllCards) {
+		return
+	}
+	g.bossDisabled = true
+	g.applyDebuffs()
+	g.eventEmitter.EmitSuccess(fmt.Sprintf("%s has been disabled", g.currentBoss.Name))
 }
 
 type PrintMode int
@@ -159,29 +319,26 @@ const (
 	PrintModeTUI
 )
 
-// NewGame creates a new game instance
-func NewGame(eventHandler EventHandler) *Game {
-	deck := NewDeck()
-	ShuffleDeck(deck)
+// RunConfig holds the choices made when setting up a new run
+type RunConfig struct {
+	// Deck is the name of the starting deck; empty means DefaultDeckName
+	Deck string
+	// Stake is the difficulty level
+	Stake Stake
+}
 
-	game := &Game{
-		totalScore:   0,
-		handsPlayed:  0,
-		discardsUsed: 0,
-		deck:         deck,
-		deckIndex:    0,
-		sortMode:     SortByRank,
-		currentAnte:  1,
-		currentBlind: SmallBlind,
-		money:        StartingMoney,
-		jokers:       []Joker{},
-		handLevels:   make(map[string]int),
-		rerollCost:   5, // Initial reroll cost
-		eventEmitter: NewEventEmitter(),
-		currentBoss:     Boss{},
-		currentBossRule: BossRuleNone,
+// NewGame creates a new game instance using the default run setup
+func NewGame(eventHandler EventHandler) *Game {
+	game, err := NewGameWithConfig(eventHandler, RunConfig{})
+	if err != nil {
+		// The default deck always exists, but fall back gracefully anyway
+		fmt.Printf("Warning: %v\n", err)
 	}
+	return game
+}
 
+// NewGameWithConfig creates a new game instance for the given run setup
+func NewGameWithConfig(eventHandler EventHandler, config RunConfig) (*Game, error) {
 	// Initialize random seed once
 	rand.Seed(time.Now().UnixNano())
 
@@ -202,21 +359,60 @@ func NewGame(eventHandler EventHandler) *Game {
 		fmt.Printf("Warning: %v\n", err)
 	}
 
-	// Set initial target
-	game.currentTarget = GetAnteRequirement(game.currentAnte, game.currentBlind)
+	// Load starting deck configurations
+	if err := LoadDeckConfigs(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// Load shop configuration
+	if err := LoadShopConfig(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	// Load booster pack configurations
+	if err := LoadPackConfigs(); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+
+	deckName := config.Deck
+	if deckName == "" {
+		deckName = DefaultDeckName
+	}
+	startingDeck, ok := GetStartingDeck(deckName)
+	var deckErr error
+	if !ok {
+		deckErr = fmt.Errorf("unknown deck %q", deckName)
+		startingDeck = StartingDeck{Name: deckName, Composition: StandardComposition}
+	}
 
-	// Deal initial hand
-	initial := game.handSize()
-	game.playerCards = make([]Card, initial)
-	copy(game.playerCards, deck[game.deckIndex:game.deckIndex+initial])
-	game.deckIndex += initial
+	deck := startingDeck.BuildCards()
+	ShuffleDeck(deck)
 
-	// Initialize display-to-original mapping
-	game.displayToOriginal = make([]int, len(game.playerCards))
-	for i := range game.playerCards {
-		game.displayToOriginal[i] = i
+	game := &Game{
+		totalScore:   0,
+		handsPlayed:  0,
+		discardsUsed: 0,
+		deck:         deck,
+		deckIndex:    0,
+		sortMode:     SortByRank,
+		currentAnte:  1,
+		currentBlind: SmallBlind,
+		money:        StartingMoney + startingDeck.Money,
+		jokers:       []OwnedJoker{},
+		handLevels:   make(map[string]int),
+		eventEmitter: NewEventEmitter(),
+		currentBoss:  Boss{},
+		startingDeck: startingDeck,
+		stake:        config.Stake,
+		phase:        PhaseBlindSelect,
 	}
 
+	// Set initial target
+	game.currentTarget = game.blindTarget(game.currentAnte, game.currentBlind)
+
+	// No hand is dealt yet: the run opens on blind select and startBlind
+	// deals once a blind is chosen
+
 	// Initialize hand levels to 1
 	for _, eval := range handEvaluators {
 		game.handLevels[eval.Name()] = 1
@@ -225,25 +421,41 @@ func NewGame(eventHandler EventHandler) *Game {
 	// Set the event handler
 	game.eventEmitter.SetEventHandler(eventHandler)
 
-	return game
+	return game, deckErr
 }
 
 // Run starts the main game loop
 func (g *Game) Run() {
 	g.eventEmitter.EmitGameStarted()
+	if g.startingDeck.Description != "" {
+		g.eventEmitter.EmitInfo(fmt.Sprintf("Starting deck: %s - %s", g.startingDeck.Name, g.startingDeck.Description))
+	}
+	if g.stake > WhiteStake {
+		g.eventEmitter.EmitInfo(fmt.Sprintf("Playing on %s: %s", g.stake, g.stake.Description()))
+	}
 
 	gameRunning := true
 	shouldSave := false
-	for gameRunning && g.currentAnte <= MaxAntes {
-		for g.handsPlayed < MaxHands && g.totalScore < g.currentTarget {
+	if g.wonRun() {
+		// Saves made by quitting at the final cash-out before endless mode
+		// was chosen
+		g.eventEmitter.EmitEvent(VictoryEvent{})
+		gameRunning = g.offerEndlessMode()
+	}
+	for gameRunning && (g.endless || g.currentAnte <= MaxAntes) {
+		if g.phase == PhaseBlindSelect {
+			if !g.selectBlind() {
+				g.eventEmitter.EmitInfo("Thanks for playing!")
+				shouldSave = true
+				break
+			}
+			g.startBlind()
+		}
+
+		for g.handsPlayed < g.maxHands() && g.totalScore < g.currentTarget {
 			// Update display mapping and emit current state
 			g.updateDisplayToOriginalMapping()
-			bossName := ""
-			if g.currentBlind == BossBlind {
-				bossName = g.currentBossRule.Description()
-			}
-			g.eventEmitter.EmitGameState(g.currentAnte, g.currentBlind, g.currentTarget, g.totalScore,
-				MaxHands-g.handsPlayed, g.maxDiscards()-g.discardsUsed, g.money, g.jokers, bossName)
+			g.emitGameState()
 			g.eventEmitter.EmitCardsDealt(g.playerCards, g.displayToOriginal, g.sortMode)
 
 			action, params, quit := g.eventEmitter.handler.GetPlayerAction(g.discardsUsed < g.maxDiscards())
@@ -263,7 +475,13 @@ func (g *Game) Run() {
 			} else if action == PlayerActionMoveJoker {
 				g.handleMoveJokerAction(params)
 			} else if action == PlayerActionSellJoker {
-				g.handleSellJokerAction(params)
+				if g.handleSellJokerAction(params) {
+					g.disableBossOnJokerSold()
+				}
+			} else if action == PlayerActionViewDeck {
+				g.handleViewDeckAction()
+			} else if action == PlayerActionUseConsumable {
+				g.handleUseConsumableAction(params)
 			}
 		}
 
@@ -273,7 +491,23 @@ func (g *Game) Run() {
 
 		// Check if blind was completed
 		if g.totalScore >= g.currentTarget {
-			g.handleBlindCompletion()
+			if !g.handleBlindCompletion() {
+				shouldSave = true
+				if g.wonRun() {
+					// Quitting at the final cash-out still wins the run; it's
+					// only saved if it carries on in endless mode
+					g.eventEmitter.EmitEvent(VictoryEvent{})
+					shouldSave = g.chooseEndlessMode()
+				}
+				g.eventEmitter.EmitInfo("Thanks for playing!")
+				break
+			}
+			if g.wonRun() {
+				g.eventEmitter.EmitEvent(VictoryEvent{})
+				if !g.offerEndlessMode() {
+					break
+				}
+			}
 		} else {
 			// Failed to beat the blind
 			g.eventEmitter.EmitEvent(GameOverEvent{
@@ -285,9 +519,7 @@ func (g *Game) Run() {
 		}
 	}
 
-	if gameRunning && g.currentAnte > MaxAntes {
-		g.eventEmitter.EmitEvent(VictoryEvent{})
-	}
+	g.recordHighestAnte()
 
 	if shouldSave {
 		if filename, err := g.Save(); err != nil {
@@ -300,6 +532,101 @@ func (g *Game) Run() {
 	g.eventEmitter.handler.Close()
 }
 
+// wonRun reports whether the final ante has been beaten and endless mode
+// hasn't been chosen yet
+func (g *Game) wonRun() bool {
+	return g.currentAnte > MaxAntes && !g.endless
+}
+
+// offerEndlessMode asks whether to keep playing after the final ante and,
+// if so, starts the first endless blind
+func (g *Game) offerEndlessMode() bool {
+	if !g.chooseEndlessMode() {
+		return false
+	}
+	g.visitShop()
+	return true
+}
+
+// chooseEndlessMode asks whether to keep playing after the final ante and
+// switches the run to endless mode if so
+func (g *Game) chooseEndlessMode() bool {
+	for {
+		action, _, quit := g.eventEmitter.handler.GetEndlessAction()
+		if quit {
+			return false
+		}
+		if action == PlayerActionContinueEndless {
+			break
+		}
+	}
+
+	g.endless = true
+	g.eventEmitter.EmitEvent(EndlessModeStartedEvent{Ante: g.currentAnte})
+	return true
+}
+
+// highestAnteReached returns the furthest ante the player has played
+func (g *Game) highestAnteReached() int {
+	if !g.endless && g.currentAnte > MaxAntes {
+		return MaxAntes
+	}
+	return g.currentAnte
+}
+
+// recordHighestAnte updates the highest ante stat and announces new records
+func (g *Game) recordHighestAnte() {
+	ante := g.highestAnteReached()
+	previous, err := RecordHighestAnte(ante)
+	if err != nil {
+		g.eventEmitter.EmitWarning(fmt.Sprintf("Could not save stats: %v", err))
+		return
+	}
+	if ante > previous && previous > 0 {
+		g.eventEmitter.EmitSuccess(fmt.Sprintf("New record! Highest ante reached: %d (previous best: %d)", ante, previous))
+	}
+}
+
+// emitGameState emits the current blind, score and resource counts
+func (g *Game) emitGameState() {
+	g.eventEmitter.EmitEvent(g.gameStateEvent())
+}
+
+// gameStateEvent describes the current game state
+func (g *Game) gameStateEvent() GameStateChangedEvent {
+	bossName := ""
+	showdown := false
+	if g.currentBlind == BossBlind {
+		bossName = g.currentBoss.Label()
+		if g.bossDisabled {
+			bossName += " (disabled)"
+		}
+		showdown = g.currentBoss.Final
+	}
+	return GameStateChangedEvent{
+		Ante:        g.currentAnte,
+		Blind:       g.currentBlind,
+		Target:      g.currentTarget,
+		Score:       g.totalScore,
+		Hands:       g.maxHands() - g.handsPlayed,
+		Discards:    g.maxDiscards() - g.discardsUsed,
+		Money:       g.money,
+		Jokers:      g.jokers,
+		JokerSlots:  g.jokerSlots(),
+		Consumables: g.consumables,
+		Vouchers:    g.vouchers,
+		Boss:        bossName,
+		Showdown:    showdown,
+	}
+}
+
+// emitEvents emits events in order
+func (g *Game) emitEvents(events []Event) {
+	for _, e := range events {
+		g.eventEmitter.EmitEvent(e)
+	}
+}
+
 // updateDisplayToOriginalMapping sorts cards and updates the display mapping
 func (g *Game) updateDisplayToOriginalMapping() {
 	// Create a sorted copy of cards with their original indices
@@ -358,6 +685,7 @@ func (g *Game) handlePlayAction(params []string) {
 	if !valid {
 		return
 	}
+	selectedCards, selectedIndices = g.withForcedCard(selectedCards, selectedIndices)
 
 	if len(selectedCards) == 0 {
 		g.eventEmitter.EmitEvent(InvalidActionEvent{
@@ -367,44 +695,153 @@ func (g *Game) handlePlayAction(params []string) {
 		return
 	}
 
-	// Apply replay effects for matching cards
-	cardsForJokers, extraCardValue := ApplyReplayCardEffects(g.jokers, selectedCards)
+	if len(selectedCards) > 5 {
+		g.eventEmitter.EmitEvent(InvalidActionEvent{
+			Action: "play",
+			Reason: fmt.Sprintf("%s must be played, so select at most 4 other cards!", selectedCards[len(selectedCards)-1]),
+		})
+		return
+	}
 
 	// Evaluate the hand
 	hand := Hand{Cards: selectedCards}
 	evaluator, _, cardValues, baseScore, mult := EvaluateHand(hand, g.handLevels)
-	cardValues += extraCardValue
-	cardValues = g.applyBossCardModifiers(selectedCards, cardValues)
+	state := g.conditionState(evaluator.Name(), selectedCards)
 
-	// Calculate joker bonuses using cards including replays
-	jokerChips, jokerMult, jokerMultFactor := CalculateJokerHandBonus(g.jokers, evaluator.Name(), cardsForJokers)
-
-	// Apply joker bonuses to final score
-	finalBaseScore := baseScore + jokerChips
-	finalMult := (evaluator.Multiplier() + jokerMult) * jokerMultFactor
-	finalScore := (finalBaseScore + cardValues) * finalMult
+	// Apply replay effects for matching cards
+	cardsForJokers, extraCardValue := ApplyReplayCardEffects(g.jokers, state, selectedCards)
+	cardValues += extraCardValue
+	baseScore, mult = g.applyBossBaseModifiers(baseScore, mult)
+
+	// Some bosses let the hand be played but score nothing
+	if reason := g.bossDisallowsHand(evaluator.Name(), len(selectedCards)); reason != "" {
+		g.eventEmitter.EmitEvent(HandPlayedEvent{
+			SelectedCards: selectedCards,
+			HandType:      evaluator.Name(),
+			NotAllowed:    reason,
+			NewTotalScore: g.totalScore,
+		})
+		g.handsPlayed++
+		g.removeAndDealCards(g.withBossDiscards(selectedIndices))
+		g.disableRandomJoker()
+		return
+	}
+	g.handTypesPlayed = append(g.handTypesPlayed, evaluator.Name())
+	held := g.heldCards(selectedIndices)
+	g.updateJokerCounters(TriggerCardScored, state, cardsForJokers)
+	g.updateJokerCounters(TriggerHeldInHand, state, held)
+	g.updateJokerCounters(TriggerHandPlayed, state, selectedCards)
+
+	// Work out joker effects card by card, retriggers included, then for
+	// cards held in hand and the hand itself
+	steps := JokerScoringSteps(g.jokers, state, selectedCards, held)
+	jokerChips, jokerMult, jokerMultFactor := sumScoringSteps(steps)
+
+	// Apply joker bonuses in order to final score
+	finalChips, finalMult := scoreHand(baseScore+cardValues, mult, steps, LegacyScoring())
+	finalScore := finalChips.Mul(finalMult)
+	g.applyJokerSteps(steps, true)
 
 	// Emit hand played event with all the details
 	g.eventEmitter.EmitEvent(HandPlayedEvent{
-		SelectedCards: selectedCards,
-		HandType:      evaluator.Name(),
-		BaseScore:     baseScore,
-		CardValues:    cardValues,
-		Multiplier:    mult,
-		JokerChips:    jokerChips,
-		JokerMult:     jokerMult,
-		FinalScore:    finalScore,
-		NewTotalScore: g.totalScore + finalScore,
+		SelectedCards:   selectedCards,
+		HandType:        evaluator.Name(),
+		BaseScore:       baseScore,
+		CardValues:      cardValues,
+		Multiplier:      mult,
+		JokerChips:      jokerChips,
+		JokerMult:       jokerMult,
 		JokerMultFactor: jokerMultFactor,
+		FinalChips:      finalChips,
+		FinalMult:       finalMult,
+		Steps:           steps,
 		FinalScore:      finalScore,
+		NewTotalScore:   g.totalScore.Add(finalScore),
 	})
 
 	// Update game state
-	g.totalScore += finalScore
+	g.totalScore = g.totalScore.Add(finalScore)
 	g.handsPlayed++
+	g.updateJokerCounters(TriggerHandScored, state, selectedCards)
 
 	// Remove played cards and deal new ones
-	g.removeAndDealCards(selectedIndices)
+	g.removeAndDealCards(g.withBossDiscards(selectedIndices))
+	g.disableRandomJoker()
+}
+
+// applyBossBaseModifiers adjusts a hand's base chips and mult for the current boss
+func (g *Game) applyBossBaseModifiers(baseScore, mult int) (int, int) {
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == HalveBaseScore {
+			baseScore = (baseScore + 1) / 2
+			mult = (mult + 1) / 2
+		}
+	}
+	return baseScore, mult
+}
+
+// bossDisallowsHand returns why the current boss refuses to score a hand,
+// or an empty string if the hand scores normally
+func (g *Game) bossDisallowsHand(handType string, numCards int) string {
+	for _, eff := range g.bossEffects() {
+		switch eff.Effect {
+		case RequireCardCount:
+			if numCards != eff.Magnitude {
+				return fmt.Sprintf("%s requires exactly %d cards", g.currentBoss.Name, eff.Magnitude)
+			}
+		case NoRepeatHandTypes:
+			for _, played := range g.handTypesPlayed {
+				if played == handType {
+					return fmt.Sprintf("%s was already played this round", handType)
+				}
+			}
+		case SingleHandType:
+			if len(g.handTypesPlayed) > 0 && g.handTypesPlayed[0] != handType {
+				return fmt.Sprintf("only %s can be played this round", g.handTypesPlayed[0])
+			}
+		}
+	}
+	return ""
+}
+
+// withBossDiscards adds random held cards for bosses that discard after each
+// hand to the played card indices
+func (g *Game) withBossDiscards(playedIndices []int) []int {
+	count := 0
+	for _, eff := range g.bossEffects() {
+		if eff.Effect == DiscardHeldCards {
+			count += eff.Magnitude
+		}
+	}
+	if count == 0 {
+		return playedIndices
+	}
+
+	played := make(map[int]bool)
+	for _, idx := range playedIndices {
+		played[idx] = true
+	}
+	var held []int
+	for i := range g.playerCards {
+		if !played[i] {
+			held = append(held, i)
+		}
+	}
+	rng.Shuffle(len(held), func(i, j int) { held[i], held[j] = held[j], held[i] })
+	if count > len(held) {
+		count = len(held)
+	}
+
+	var names []string
+	indices := append([]int{}, playedIndices...)
+	for _, idx := range held[:count] {
+		indices = append(indices, idx)
+		names = append(names, g.playerCards[idx].String())
+	}
+	if len(names) > 0 {
+		g.eventEmitter.EmitWarning(fmt.Sprintf("%s discards %s", g.currentBoss.Name, strings.Join(names, " ")))
+	}
+	return indices
 }
 
 // handleDiscardAction processes a discard action
@@ -429,6 +866,7 @@ func (g *Game) handleDiscardAction(params []string) {
 	if !valid {
 		return
 	}
+	selectedCards, selectedIndices = g.withForcedCard(selectedCards, selectedIndices)
 
 	if len(selectedIndices) == 0 {
 		g.eventEmitter.EmitEvent(InvalidActionEvent{
@@ -440,6 +878,8 @@ func (g *Game) handleDiscardAction(params []string) {
 
 	// Update discard count
 	g.discardsUsed++
+	g.updateJokerCounters(TriggerDiscard, g.conditionState("", nil), selectedCards)
+	g.fireJokers(TriggerDiscard, g.conditionState("", nil), selectedCards)
 
 	// Emit discard event before removing cards
 	g.eventEmitter.EmitEvent(CardsDiscardedEvent{
@@ -497,30 +937,83 @@ func (g *Game) removeAndDealCards(selectedIndices []int) {
 		}
 	}
 
+	g.applyDebuffs()
+	g.applyForcedSelection()
+
 	// Update display mapping after dealing new cards to maintain sort order
 	g.updateDisplayToOriginalMapping()
 }
 
-// handleBlindCompletion handles completing a blind and advancing to the next
-func (g *Game) handleBlindCompletion() {
+// addCard adds a card to the deck for the rest of the run
+func (g *Game) addCard(card Card) {
+	g.deck = append(g.deck, card)
+	g.addedCards = append(g.addedCards, card)
+}
 
-	// Calculate and award money with detailed breakdown
-	var baseReward int
-	switch g.currentBlind {
-	case SmallBlind:
-		baseReward = SmallBlindReward
-	case BigBlind:
-		baseReward = BigBlindReward
-	case BossBlind:
-		baseReward = BossBlindReward
+// destroyCard removes a card from the deck for the rest of the run,
+// reporting whether it was found
+func (g *Game) destroyCard(card Card) bool {
+	for i, c := range g.deck {
+		if c.Suit != card.Suit || c.Rank != card.Rank || c.Gold != card.Gold || c.Seal != card.Seal {
+			continue
+		}
+		g.deck = append(g.deck[:i], g.deck[i+1:]...)
+		if i < g.deckIndex {
+			g.deckIndex--
+		}
+		g.destroyedCards = append(g.destroyedCards, Card{Suit: card.Suit, Rank: card.Rank, Gold: card.Gold, Seal: card.Seal})
+		return true
 	}
+	return false
+}
 
-	unusedHands := MaxHands - g.handsPlayed
+// handleBlindCompletion pays out a defeated blind, waits for the player to
+// cash out and advances to the next blind. It returns false if the player
+// quits at the cash-out screen.
+func (g *Game) handleBlindCompletion() bool {
+	// Itemize the rewards; interest is counted on the money held beforehand
+	baseReward := g.blindReward(g.currentBlind)
+	lines := []RewardLine{{Kind: RewardBlind, Label: g.currentBlind.String(), Amount: baseReward}}
+
+	unusedHands := g.maxHands() - g.handsPlayed
 	unusedDiscards := g.maxDiscards() - g.discardsUsed
+	for i := 0; i < unusedHands; i++ {
+		lines = append(lines, RewardLine{Kind: RewardUnusedHand, Label: "Unused hand", Amount: UnusedHandReward})
+	}
+	for i := 0; i < unusedDiscards; i++ {
+		lines = append(lines, RewardLine{Kind: RewardUnusedDiscard, Label: "Unused discard", Amount: UnusedDiscardReward})
+	}
 	bonusReward := unusedHands*UnusedHandReward + unusedDiscards*UnusedDiscardReward
-	jokerReward := CalculateJokerRewards(g.jokers)
-	totalReward := baseReward + bonusReward + jokerReward
 
+	jokerLines := jokerMoneyLines(g.jokers, TriggerBlindEnd, g.conditionState("", nil), nil)
+	jokerReward := sumRewards(jokerLines)
+	lines = append(lines, jokerLines...)
+
+	interest := g.interest()
+	if interest > 0 {
+		label := fmt.Sprintf("Interest ($%d per $%d, max $%d)", GetInterest().Amount, GetInterest().Step, g.interestCap())
+		lines = append(lines, RewardLine{Kind: RewardInterest, Label: label, Amount: interest})
+	}
+
+	goldLines := g.goldCardLines()
+	goldReward := sumRewards(goldLines)
+	lines = append(lines, goldLines...)
+
+	tagReward := 0
+	if g.currentBlind == BossBlind {
+		for i := g.useTags(InvestmentTag); i > 0; i-- {
+			lines = append(lines, RewardLine{Kind: RewardTag, Label: string(InvestmentTag), Amount: InvestmentTagPayout})
+			tagReward += InvestmentTagPayout
+		}
+	}
+
+	g.fireJokers(TriggerBlindEnd, g.conditionState("", nil), nil)
+	g.updateJokerCounters(TriggerBlindEnd, g.conditionState("", nil), nil)
+	rentalLines := g.endRoundStickers(g.money + sumRewards(lines))
+	rentalCost := -sumRewards(rentalLines)
+	lines = append(lines, rentalLines...)
+
+	totalReward := sumRewards(lines)
 	g.money += totalReward
 
 	// Emit blind defeated event with all reward information
@@ -531,25 +1024,29 @@ func (g *Game) handleBlindCompletion() {
 		BaseReward:     baseReward,
 		BonusReward:    bonusReward,
 		JokerReward:    jokerReward,
+		Interest:       interest,
+		GoldReward:     goldReward,
+		TagReward:      tagReward,
+		RentalCost:     rentalCost,
 		TotalReward:    totalReward,
 		NewMoney:       g.money,
 		UnusedHands:    unusedHands,
 		UnusedDiscards: unusedDiscards,
+		Lines:          lines,
 	})
+	cashedOut := g.cashOut()
 
 	// Advance to next blind
 	if g.currentBlind == SmallBlind {
 		g.currentBlind = BigBlind
 	} else if g.currentBlind == BigBlind {
 		g.currentBlind = BossBlind
-		g.currentBossRule = randomBossRule()
 	} else {
 		// Completed Boss Blind, advance to next ante
 		oldAnte := g.currentAnte
 		g.currentAnte++
 		g.currentBlind = SmallBlind
-		g.currentBossRule = BossRuleNone
-		if g.currentAnte <= MaxAntes {
+		if g.endless || g.currentAnte <= MaxAntes {
 			g.eventEmitter.EmitEvent(AnteCompletedEvent{
 				CompletedAnte: oldAnte,
 				NewAnte:       g.currentAnte,
@@ -557,359 +1054,267 @@ func (g *Game) handleBlindCompletion() {
 		}
 	}
 
-	if g.currentAnte <= MaxAntes {
-		// Reset for next blind
-		g.totalScore = 0
-		g.handsPlayed = 0
-		g.discardsUsed = 0
-		g.rerollCost = 5 // Reset reroll cost for new blind
-		g.currentTarget = GetAnteRequirement(g.currentAnte, g.currentBlind)
-
-		if g.currentBlind == BossBlind {
-			g.currentBoss = GetBossForAnte(g.currentAnte)
-			g.applyBossEffect()
-		} else {
-			g.currentBoss = Boss{}
-		}
-
-		// Shuffle and deal new hand
-		g.deckIndex = 0
-		ShuffleDeck(g.deck)
-		handSize := g.handSize()
-		g.playerCards = make([]Card, handSize)
-		copy(g.playerCards, g.deck[g.deckIndex:g.deckIndex+handSize])
-		g.deckIndex += handSize
+	if !cashedOut {
+		// The shop is skipped and the run resumes at the next blind
+		g.phase = PhaseBlindSelect
+		return false
+	}
+	if g.endless || g.currentAnte <= MaxAntes {
+		g.visitShop()
+	}
+	return true
+}
 
-		// Show next blind info
-		var boss *Boss
-		if g.currentBlind == BossBlind {
-			boss = &g.currentBoss
+// cashOut waits for the player to collect a defeated blind's rewards. It
+// returns false if the player quits instead.
+func (g *Game) cashOut() bool {
+	for {
+		action, _, quit := g.eventEmitter.handler.GetCashOutAction()
+		if quit {
+			return false
 		}
-		g.eventEmitter.EmitEvent(NewBlindStartedEvent{
-			Ante:     g.currentAnte,
-			Blind:    g.currentBlind,
-			Target:   g.currentTarget,
-			NewCards: g.playerCards,
-			Boss:     boss,
-		})
-		if g.currentBlind == BossBlind {
-			g.eventEmitter.EmitInfo(fmt.Sprintf("Boss effect: %s", g.currentBossRule.Description()))
+		if action == PlayerActionCashOut {
+			return true
 		}
-
-		// Show shop between blinds
-		g.showShop()
 	}
 }
 
-// showShop displays the shop interface between blinds
-func (g *Game) showShop() {
-	// Get all jokers player doesn't own
-	allJokers := GetAvailableJokers()
-	var availableJokers []Joker
-	for _, joker := range allJokers {
-		if !PlayerHasJoker(g.jokers, joker.Name) {
-			availableJokers = append(availableJokers, joker)
+// goldCardLines itemizes the Gold cards held in hand
+func (g *Game) goldCardLines() []RewardLine {
+	var lines []RewardLine
+	for _, card := range g.playerCards {
+		if card.Gold && !card.Debuffed {
+			lines = append(lines, RewardLine{Kind: RewardGoldCard, Label: fmt.Sprintf("Gold card %s", card), Amount: GoldCardReward})
 		}
 	}
+	return lines
+}
 
-	// If no jokers available, skip shop
-	if len(availableJokers) == 0 {
-		g.eventEmitter.EmitMessage("All available jokers already owned!", "info")
-		return
-	}
-
-	// Randomly select up to 2 jokers for shop
-	shopItems := make([]Joker, 0, 2)
-	if len(availableJokers) >= 2 {
-		// Create a copy and shuffle it
-		shuffled := make([]Joker, len(availableJokers))
-		copy(shuffled, availableJokers)
-
-		// Fisher-Yates shuffle
-		for i := len(shuffled) - 1; i > 0; i-- {
-			j := rand.Intn(i + 1)
-			shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-		}
+// interestCap returns the most interest paid per blind
+func (g *Game) interestCap() int {
+	return GetInterest().Cap + g.voucherBonus(VoucherInterestCap)
+}
 
-		shopItems = shuffled[:2]
-	} else {
-		shopItems = availableJokers
+// interest returns the interest earned on the money held when a blind is
+// defeated
+func (g *Game) interest() int {
+	if g.money <= 0 {
+		return 0
+	}
+	rate := GetInterest()
+	earned := g.money / rate.Step * rate.Amount
+	if limit := g.interestCap(); earned > limit {
+		earned = limit
 	}
+	return earned
+}
 
-	// Convert jokers to shop item data
-	var items []ShopItemData
-	for _, joker := range shopItems {
-		if joker.Name != "" {
-			items = append(items, NewShopItemData(joker, g.money))
-		} else {
-			// Preserve empty slots so indices remain stable
-			items = append(items, ShopItemData{})
+// blindReward returns the base money paid for defeating a blind
+func (g *Game) blindReward(blind BlindType) int {
+	switch blind {
+	case SmallBlind:
+		if g.stake.SmallBlindPaysReward() {
+			return SmallBlindReward
 		}
+	case BigBlind:
+		return BigBlindReward
+	case BossBlind:
+		return BossBlindReward
 	}
+	return 0
+}
 
-	// Emit shop opened event
-	g.eventEmitter.EmitEvent(ShopOpenedEvent{
-		Money:      g.money,
-		RerollCost: g.rerollCost,
-		Items:      items,
-	})
+// visitShop resets round state and opens the shop; the next blind is chosen
+// once the player leaves
+func (g *Game) visitShop() {
+	// Reset for next blind
+	g.totalScore = 0
+	g.handsPlayed = 0
+	g.discardsUsed = 0
+	g.handTypesPlayed = nil
+	g.bossDisabled = false
+	g.clearBossJokerEffects()
+
+	g.runShop(g.newShop())
+	g.phase = PhaseBlindSelect
+}
 
+// selectBlind offers the ante's blinds and lets the player skip Small and
+// Big Blinds for a tag until they choose one to play. It returns false if
+// the player quits instead.
+func (g *Game) selectBlind() bool {
+	g.eventEmitter.EmitEvent(g.blindSelectionEvent())
 	for {
-		action, params, quit := g.eventEmitter.handler.GetShopAction()
+		action, _, quit := g.eventEmitter.handler.GetBlindAction()
 		if quit {
-			return
+			return false
 		}
-
-		if action == PlayerActionExitShop {
-			g.eventEmitter.EmitEvent(ShopClosedEvent{})
-			return
-		} else if action == PlayerActionReroll {
-			if g.money >= g.rerollCost {
-				oldCost := g.rerollCost
-				g.money -= g.rerollCost
-				g.rerollCost += 2
-
-				// Generate new shop items
-				if len(availableJokers) >= 2 {
-					shuffled := make([]Joker, len(availableJokers))
-					copy(shuffled, availableJokers)
-					for i := len(shuffled) - 1; i > 0; i-- {
-						j := rand.Intn(i + 1)
-						shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-					}
-					shopItems = shuffled[:2]
-				} else {
-					shopItems = availableJokers
-				}
-
-				// Convert new items and emit reroll event
-				var newItems []ShopItemData
-				for _, joker := range shopItems {
-					if joker.Name != "" {
-						newItems = append(newItems, NewShopItemData(joker, g.money))
-					} else {
-						newItems = append(newItems, ShopItemData{})
-					}
-				}
-
-				g.eventEmitter.EmitEvent(ShopRerolledEvent{
-					Cost:           oldCost,
-					NewRerollCost:  g.rerollCost,
-					RemainingMoney: g.money,
-					NewItems:       newItems,
-				})
-
-				// Recursively call shop with new items
-				g.showShopWithItems(availableJokers, shopItems)
-				return
-			} else {
-				g.eventEmitter.EmitEvent(InvalidActionEvent{
-					Action: "reroll",
-					Reason: fmt.Sprintf("Not enough money to reroll! Need $%d more.", g.rerollCost-g.money),
-				})
-			}
-		} else if action == PlayerActionMoveJoker {
-			g.handleMoveJokerAction(params)
-		} else if action == PlayerActionSellJoker {
-			g.handleSellJokerAction(params)
-		} else if action == PlayerActionBuy {
-			choice := params[0]
-			if choice, err := strconv.Atoi(choice); err == nil && choice >= 1 && choice <= len(shopItems) {
-				selectedJoker := shopItems[choice-1]
-				if selectedJoker.Name == "" {
-					g.eventEmitter.EmitEvent(InvalidActionEvent{
-						Action: "buy",
-						Reason: "That slot is empty!",
-					})
-					continue
-				}
-
-				if g.money >= selectedJoker.Price {
-					g.money -= selectedJoker.Price
-					g.jokers = append(g.jokers, selectedJoker)
-
-					g.eventEmitter.EmitEvent(ShopItemPurchasedEvent{
-						Item:           NewShopItemData(selectedJoker, g.money+selectedJoker.Price),
-						RemainingMoney: g.money,
-					})
-					bossName := ""
-					if g.currentBlind == BossBlind {
-						bossName = g.currentBossRule.Description()
-					}
-					g.eventEmitter.EmitGameState(g.currentAnte, g.currentBlind, g.currentTarget, g.totalScore,
-						MaxHands-g.handsPlayed, g.maxDiscards()-g.discardsUsed, g.money, g.jokers, bossName)
-
-					// Remove purchased item and update available jokers
-					shopItems[choice-1] = Joker{}
-					for i, joker := range availableJokers {
-						if joker.Name == selectedJoker.Name {
-							availableJokers = append(availableJokers[:i], availableJokers[i+1:]...)
-							break
-						}
-					}
-
-					g.showShopWithItems(availableJokers, shopItems)
-					return
-				} else {
-					g.eventEmitter.EmitEvent(InvalidActionEvent{
-						Action: "buy",
-						Reason: fmt.Sprintf("Not enough money! Need $%d more.", selectedJoker.Price-g.money),
-					})
-				}
-			} else {
-				g.eventEmitter.EmitEvent(InvalidActionEvent{
-					Action: "buy",
-					Reason: fmt.Sprintf("Invalid item number (given %v).", params),
-				})
-			}
-		} else {
+		if action == PlayerActionSelectBlind {
+			return true
+		}
+		if action != PlayerActionSkipBlind {
+			continue
+		}
+		if g.currentBlind == BossBlind {
 			g.eventEmitter.EmitEvent(InvalidActionEvent{
-				Action: "unknown",
-				Reason: fmt.Sprintf("Invalid action (given '%s'). Use 'buy <number>', 'reroll', or 'exit'.", action),
+				Action: "skip_blind",
+				Reason: "The Boss Blind can't be skipped",
 			})
+			continue
 		}
+
+		tag := TagForBlind(g.currentAnte, g.currentBlind)
+		g.eventEmitter.EmitEvent(BlindSkippedEvent{Ante: g.currentAnte, Blind: g.currentBlind, Tag: tag})
+		g.currentBlind++
+		g.addTag(tag)
+		g.eventEmitter.EmitEvent(g.blindSelectionEvent())
 	}
 }
 
-// showShopWithItems is a helper function to continue shop with specific items
-func (g *Game) showShopWithItems(availableJokers []Joker, shopItems []Joker) {
-	// Convert jokers to shop item data for display
-	var items []ShopItemData
-	for _, joker := range shopItems {
-		if joker.Name != "" {
-			items = append(items, NewShopItemData(joker, g.money))
+// blindSelectionEvent previews the current ante's blinds, their targets,
+// rewards, skip tags and boss
+func (g *Game) blindSelectionEvent() BlindSelectionEvent {
+	event := BlindSelectionEvent{
+		Ante:    g.currentAnte,
+		Current: g.currentBlind,
+		Tags:    append([]Tag{}, g.tags...),
+	}
+	for blind := SmallBlind; blind <= BossBlind; blind++ {
+		preview := BlindPreview{
+			Blind:  blind,
+			Target: g.blindTarget(g.currentAnte, blind),
+			Reward: g.blindReward(blind),
+		}
+		if blind == BossBlind {
+			boss := GetBossForAnte(g.currentAnte)
+			preview.Boss = &boss
+			preview.Target = boss.ScaleTarget(preview.Target)
 		} else {
-			// Keep placeholder to maintain indexing
-			items = append(items, ShopItemData{})
+			tag := TagForBlind(g.currentAnte, blind)
+			preview.Tag = &tag
 		}
+		event.Blinds = append(event.Blinds, preview)
 	}
+	return event
+}
 
-	// Check if shop is empty
-	if len(items) == 0 {
-		g.eventEmitter.EmitMessage("Shop sold out!", "info")
-		g.eventEmitter.handler.GetShopAction() // Wait for user to continue
-		return
+// startBlind sets the target and boss for the chosen blind, deals a fresh
+// hand and begins play
+func (g *Game) startBlind() {
+	g.currentTarget = g.blindTarget(g.currentAnte, g.currentBlind)
+
+	if g.currentBlind == BossBlind {
+		g.currentBoss = GetBossForAnte(g.currentAnte)
+		g.applyBossEffect()
+	} else {
+		g.currentBoss = Boss{}
 	}
 
-	// Emit shop opened event with current items
-	g.eventEmitter.EmitEvent(ShopOpenedEvent{
-		Money:      g.money,
-		RerollCost: g.rerollCost,
-		Items:      items,
+	g.dealHand()
+
+	// Show next blind info
+	var boss *Boss
+	if g.currentBlind == BossBlind {
+		boss = &g.currentBoss
+	}
+	g.eventEmitter.EmitEvent(NewBlindStartedEvent{
+		Ante:     g.currentAnte,
+		Blind:    g.currentBlind,
+		Target:   g.currentTarget,
+		NewCards: g.playerCards,
+		Boss:     boss,
 	})
+	if g.currentBlind == BossBlind {
+		g.eventEmitter.EmitInfo(fmt.Sprintf("Boss effect: %s", g.currentBoss.Describe()))
+	}
 
-	for {
-		action, params, quit := g.eventEmitter.handler.GetShopAction()
-		if quit {
-			return
-		}
+	g.applyBossJokerEffects()
+	g.updateJokerCounters(TriggerBlindSelect, g.conditionState("", nil), nil)
+	g.fireJokers(TriggerBlindSelect, g.conditionState("", nil), nil)
+	g.phase = PhasePlaying
+}
 
-		if action == PlayerActionExitShop {
-			g.eventEmitter.EmitEvent(ShopClosedEvent{})
-			return
-		} else if action == PlayerActionReroll {
-			if g.money >= g.rerollCost {
-				oldCost := g.rerollCost
-				g.money -= g.rerollCost
-				g.rerollCost += 2
-
-				// Generate new shop items
-				if len(availableJokers) >= 2 {
-					shuffled := make([]Joker, len(availableJokers))
-					copy(shuffled, availableJokers)
-					for i := len(shuffled) - 1; i > 0; i-- {
-						j := rand.Intn(i + 1)
-						shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
-					}
-					shopItems = shuffled[:2]
-				} else {
-					shopItems = availableJokers
-				}
+// dealHand shuffles the whole deck and deals a fresh hand, marking the
+// cards the boss debuffs or forces
+func (g *Game) dealHand() {
+	g.deckIndex = 0
+	ShuffleDeck(g.deck)
+	handSize := g.handSize()
+	if handSize > len(g.deck) {
+		handSize = len(g.deck)
+	}
+	g.playerCards = make([]Card, handSize)
+	copy(g.playerCards, g.deck[g.deckIndex:g.deckIndex+handSize])
+	g.deckIndex += handSize
+	g.applyDebuffs()
+	g.applyForcedSelection()
+	g.updateDisplayToOriginalMapping()
+}
 
-				// Convert new items and emit reroll event
-				var newItems []ShopItemData
-				for _, joker := range shopItems {
-					if joker.Name != "" {
-						newItems = append(newItems, NewShopItemData(joker, g.money))
-					} else {
-						newItems = append(newItems, ShopItemData{})
-					}
-				}
+// heldCards returns the cards left in hand when the selected cards are played
+func (g *Game) heldCards(selectedIndices []int) []Card {
+	selected := make(map[int]bool, len(selectedIndices))
+	for _, i := range selectedIndices {
+		selected[i] = true
+	}
+	var held []Card
+	for i, card := range g.playerCards {
+		if !selected[i] {
+			held = append(held, card)
+		}
+	}
+	return held
+}
 
-				g.eventEmitter.EmitEvent(ShopRerolledEvent{
-					Cost:           oldCost,
-					NewRerollCost:  g.rerollCost,
-					RemainingMoney: g.money,
-					NewItems:       newItems,
-				})
+// endRoundStickers ages Perishable jokers and itemizes the rent owed for
+// Rental jokers at the end of a round. Rent is paid out of available and
+// never takes the player below $0.
+func (g *Game) endRoundStickers(available int) []RewardLine {
+	var rent []RewardLine
+	for i := range g.jokers {
+		switch g.jokers[i].Sticker {
+		case PerishableSticker:
+			if g.jokers[i].RoundsLeft > 0 {
+				g.jokers[i].RoundsLeft--
+				if g.jokers[i].RoundsLeft == 0 {
+					g.jokers[i].Debuffed = true
+					g.eventEmitter.EmitWarning(fmt.Sprintf("%s has perished and is now debuffed", g.jokers[i].Name))
+				}
+			}
+		case RentalSticker:
+			owed := min(RentalCostPerRound, max(available, 0))
+			available -= owed
+			rent = append(rent, RewardLine{Kind: RewardRental, Label: fmt.Sprintf("Rent for %s", g.jokers[i].Name), Amount: -owed})
+		}
+	}
+	return rent
+}
 
-				g.showShopWithItems(availableJokers, shopItems)
+// runShop shows the shop and handles the player's actions until they leave
+func (g *Game) runShop(shop *Shop) {
+	g.emitEvents(shop.Open())
+	for !shop.Closed() {
+		if shop.Opening() {
+			action, params, quit := g.eventEmitter.handler.GetPackAction()
+			if quit {
 				return
-			} else {
-				g.eventEmitter.EmitEvent(InvalidActionEvent{
-					Action: "reroll",
-					Reason: fmt.Sprintf("Not enough money to reroll! Need $%d more.", g.rerollCost-g.money),
-				})
 			}
-		} else if action == PlayerActionMoveJoker {
-			g.handleMoveJokerAction(params)
-		} else if action == PlayerActionSellJoker {
-			g.handleSellJokerAction(params)
-		} else if action == PlayerActionBuy {
-			choice := params[0]
-			if choice, err := strconv.Atoi(choice); err == nil && choice >= 1 && choice <= len(shopItems) {
-				selectedJoker := shopItems[choice-1]
-				if selectedJoker.Name == "" {
-					g.eventEmitter.EmitEvent(InvalidActionEvent{
-						Action: "buy",
-						Reason: "That slot is empty!",
-					})
-					continue
-				}
+			g.emitEvents(shop.Handle(action, params))
+			continue
+		}
 
-				if g.money >= selectedJoker.Price {
-					g.money -= selectedJoker.Price
-					g.jokers = append(g.jokers, selectedJoker)
-
-					g.eventEmitter.EmitEvent(ShopItemPurchasedEvent{
-						Item:           NewShopItemData(selectedJoker, g.money+selectedJoker.Price),
-						RemainingMoney: g.money,
-					})
-					bossName := ""
-					if g.currentBlind == BossBlind {
-						bossName = g.currentBossRule.Description()
-					}
-					g.eventEmitter.EmitGameState(g.currentAnte, g.currentBlind, g.currentTarget, g.totalScore,
-						MaxHands-g.handsPlayed, g.maxDiscards()-g.discardsUsed, g.money, g.jokers, bossName)
-
-					// Remove purchased item
-					shopItems[choice-1] = Joker{}
-					for i, joker := range availableJokers {
-						if joker.Name == selectedJoker.Name {
-							availableJokers = append(availableJokers[:i], availableJokers[i+1:]...)
-							break
-						}
-					}
-
-					g.showShopWithItems(availableJokers, shopItems)
-					return
-				} else {
-					g.eventEmitter.EmitEvent(InvalidActionEvent{
-						Action: "buy",
-						Reason: fmt.Sprintf("Not enough money! Need $%d more.", selectedJoker.Price-g.money),
-					})
-				}
-			} else {
-				g.eventEmitter.EmitEvent(InvalidActionEvent{
-					Action: "buy",
-					Reason: fmt.Sprintf("Invalid item number (given %v).", params),
-				})
-			}
-		} else {
-			g.eventEmitter.EmitEvent(InvalidActionEvent{
-				Action: "unknown",
-				Reason: fmt.Sprintf("Invalid action (given '%s'). Use 'buy <number>', 'reroll', or 'exit'.", action),
-			})
+		action, params, quit := g.eventEmitter.handler.GetShopAction()
+		if quit {
+			return
+		}
+
+		switch action {
+		case PlayerActionMoveJoker:
+			g.handleMoveJokerAction(params)
+		case PlayerActionUseConsumable:
+			g.handleUseConsumableAction(params)
+		default:
+			g.emitEvents(shop.Handle(action, params))
 		}
 	}
 }
@@ -958,6 +1363,19 @@ func (g *Game) handleResortAction() {
 	g.updateDisplayToOriginalMapping()
 }
 
+// handleViewDeckAction emits the drawn and remaining portions of the deck
+func (g *Game) handleViewDeckAction() {
+	drawn := make([]Card, g.deckIndex)
+	copy(drawn, g.deck[:g.deckIndex])
+	remaining := make([]Card, len(g.deck)-g.deckIndex)
+	copy(remaining, g.deck[g.deckIndex:])
+
+	g.eventEmitter.EmitEvent(DeckViewedEvent{
+		Drawn:     drawn,
+		Remaining: remaining,
+	})
+}
+
 // handleMoveJokerAction moves a joker up or down in the player's joker list
 func (g *Game) handleMoveJokerAction(params []string) {
 	if len(params) != 2 {
@@ -1006,57 +1424,70 @@ func (g *Game) handleMoveJokerAction(params []string) {
 		return
 	}
 
-	bossName := ""
-	if g.currentBlind == BossBlind {
-		bossName = g.currentBossRule.Description()
-	}
-	g.eventEmitter.EmitGameState(g.currentAnte, g.currentBlind, g.currentTarget, g.totalScore,
-		MaxHands-g.handsPlayed, g.maxDiscards()-g.discardsUsed, g.money, g.jokers, bossName)
+	g.emitGameState()
 }
 
-// handleSellJokerAction removes a joker and refunds half its price
-func (g *Game) handleSellJokerAction(params []string) {
-	if len(params) != 1 {
+// handleSellJokerAction removes a joker and refunds half its price,
+// reporting whether a joker was sold
+func (g *Game) handleSellJokerAction(params []string) bool {
+	idx, ok := parseIndexParam(params)
+	if !ok {
 		g.eventEmitter.EmitEvent(InvalidActionEvent{
 			Action: "sell_joker",
 			Reason: "Usage: sell_joker <index>",
 		})
-		return
+		return false
 	}
 
-	idx, err := strconv.Atoi(params[0])
-	if err != nil || idx < 1 || idx > len(g.jokers) {
-		g.eventEmitter.EmitEvent(InvalidActionEvent{
+	events, sold := g.sellJoker(idx)
+	g.emitEvents(events)
+	return sold
+}
+
+// sellJoker sells the joker at idx (numbered from 1), returning the events
+// that describe the sale and whether it went through
+func (g *Game) sellJoker(idx int) ([]Event, bool) {
+	if idx < 1 || idx > len(g.jokers) {
+		return []Event{InvalidActionEvent{
 			Action: "sell_joker",
-			Reason: fmt.Sprintf("Invalid joker number: %s", params[0]),
-		})
-		return
+			Reason: fmt.Sprintf("Invalid joker number: %d", idx),
+		}}, false
 	}
 
 	i := idx - 1
 	sold := g.jokers[i]
+	name, _ := sold.Face()
+	if sold.Sticker == EternalSticker {
+		return []Event{InvalidActionEvent{
+			Action: "sell_joker",
+			Reason: fmt.Sprintf("%s is Eternal and can't be sold", name),
+		}}, false
+	}
 	g.jokers = append(g.jokers[:i], g.jokers[i+1:]...)
-	refund := sold.Price / 2
+	refund := sold.SellValue()
 	g.money += refund
 
-	g.eventEmitter.EmitEvent(MessageEvent{
-		Message: fmt.Sprintf("Sold %s for $%d", sold.Name, refund),
-		Type:    "success",
-	})
-	bossName := ""
-	if g.currentBlind == BossBlind {
-		bossName = g.currentBossRule.Description()
-	}
-	g.eventEmitter.EmitGameState(g.currentAnte, g.currentBlind, g.currentTarget, g.totalScore,
-		MaxHands-g.handsPlayed, g.maxDiscards()-g.discardsUsed, g.money, g.jokers, bossName)
+	return []Event{
+		MessageEvent{
+			Message: fmt.Sprintf("Sold %s for $%d", name, refund),
+			Type:    "success",
+		},
+		g.gameStateEvent(),
+	}, true
 }
 
-// applyBossEffect modifies game state based on the current boss's effect
+// applyBossEffect applies the current boss's one-off effects when the blind starts
 func (g *Game) applyBossEffect() {
-	switch g.currentBoss.Effect {
-	case DoubleChips:
-		g.currentTarget *= 2
-	case HalveMoney:
-		g.money /= 2
+	g.applyBossTargetEffects()
+	for _, eff := range g.currentBoss.Effects {
+		switch eff.Effect {
+		case HalveMoney:
+			g.money /= 2
+		}
 	}
 }
+
+// applyBossTargetEffects adjusts the blind target for the current boss
+func (g *Game) applyBossTargetEffects() {
+	g.currentTarget = g.currentBoss.ScaleTarget(g.currentTarget)
+}
@@ -1,5 +1,7 @@
 package game
 
+import "fmt"
+
 // Event represents something that happened in the game
 type Event interface {
 	EventType() string
@@ -20,6 +22,21 @@ const (
 	PlayerActionBuy       = "buy"
 	PlayerActionMoveJoker = "move_joker"
 	PlayerActionSellJoker = "sell_joker"
+	PlayerActionViewDeck  = "view_deck"
+	// PlayerActionContinueEndless keeps playing past the final ante
+	PlayerActionContinueEndless = "continue_endless"
+	// PlayerActionSelectBlind plays the blind on offer
+	PlayerActionSelectBlind = "select_blind"
+	// PlayerActionSkipBlind skips the blind on offer in exchange for its tag
+	PlayerActionSkipBlind = "skip_blind"
+	// PlayerActionCashOut collects the rewards for a defeated blind
+	PlayerActionCashOut = "cash_out"
+	// PlayerActionUseConsumable uses one of the player's consumables
+	PlayerActionUseConsumable = "use_consumable"
+	// PlayerActionPickCard takes a card from an opened booster pack
+	PlayerActionPickCard = "pick_card"
+	// PlayerActionSkipPack discards the rest of an opened booster pack
+	PlayerActionSkipPack = "skip_pack"
 )
 
 // EventHandler processes game events and decides how to present them
@@ -28,6 +45,14 @@ type EventHandler interface {
 	// Input requests return through channels or direct calls
 	GetPlayerAction(canDiscard bool) (action PlayerAction, params []string, quit bool)
 	GetShopAction() (action PlayerAction, params []string, quit bool)
+	// GetEndlessAction asks whether to continue into endless mode after victory
+	GetEndlessAction() (action PlayerAction, params []string, quit bool)
+	// GetBlindAction asks whether to play or skip the blind on offer
+	GetBlindAction() (action PlayerAction, params []string, quit bool)
+	// GetCashOutAction waits for the player to collect a defeated blind's rewards
+	GetCashOutAction() (action PlayerAction, params []string, quit bool)
+	// GetPackAction asks which card to take from an opened booster pack
+	GetPackAction() (action PlayerAction, params []string, quit bool)
 	Close()
 }
 
@@ -37,8 +62,8 @@ type GameStartedEvent struct{}
 func (e GameStartedEvent) EventType() string { return "game_started" }
 
 type GameOverEvent struct {
-	FinalScore int
-	Target     int
+	FinalScore Score
+	Target     Score
 	Ante       int
 }
 
@@ -48,17 +73,32 @@ type VictoryEvent struct{}
 
 func (e VictoryEvent) EventType() string { return "victory" }
 
+// EndlessModeStartedEvent is emitted when the player keeps going past the final ante
+type EndlessModeStartedEvent struct {
+	Ante int
+}
+
+func (e EndlessModeStartedEvent) EventType() string { return "endless_mode_started" }
+
 // Game state events
 type GameStateChangedEvent struct {
 	Ante     int
 	Blind    BlindType
-	Target   int
-	Score    int
+	Target   Score
+	Score    Score
 	Hands    int
 	Discards int
 	Money    int
-	Jokers   []Joker
+	Jokers   []OwnedJoker
+	// JokerSlots is how many non-Negative jokers the player can hold
+	JokerSlots int
+	// Consumables are the single-use cards the player holds
+	Consumables []Consumable
+	// Vouchers are the upgrades the player has redeemed
+	Vouchers []Voucher
 	Boss     string
+	// Showdown is set during a final boss blind
+	Showdown bool
 }
 
 func (e GameStateChangedEvent) EventType() string { return "game_state_changed" }
@@ -80,9 +120,17 @@ type HandPlayedEvent struct {
 	Multiplier      int
 	JokerChips      int
 	JokerMult       int
-	JokerMultFactor int
-	FinalScore      int
-	NewTotalScore   int
+	JokerMultFactor Score
+	// FinalChips and FinalMult are the chips and mult after every joker
+	// has fired in order
+	FinalChips Score
+	FinalMult  Score
+	// Steps are the joker effects in the order they were applied
+	Steps         []ScoringStep
+	FinalScore    Score
+	NewTotalScore Score
+	// NotAllowed explains why a boss made the hand score nothing
+	NotAllowed string
 }
 
 func (e HandPlayedEvent) EventType() string { return "hand_played" }
@@ -101,18 +149,72 @@ type CardsResortedEvent struct {
 
 func (e CardsResortedEvent) EventType() string { return "cards_resorted" }
 
+// DeckViewedEvent describes the full deck split into cards already drawn this
+// blind (including the current hand) and cards still in the draw pile.
+type DeckViewedEvent struct {
+	Drawn     []Card
+	Remaining []Card
+}
+
+func (e DeckViewedEvent) EventType() string { return "deck_viewed" }
+
 // Blind progression events
+// RewardKind identifies what a cash-out line item pays for
+type RewardKind string
+
+const (
+	RewardBlind         RewardKind = "blind"
+	RewardUnusedHand    RewardKind = "unused_hand"
+	RewardUnusedDiscard RewardKind = "unused_discard"
+	RewardJoker         RewardKind = "joker"
+	RewardInterest      RewardKind = "interest"
+	RewardGoldCard      RewardKind = "gold_card"
+	RewardTag           RewardKind = "tag"
+	RewardRental        RewardKind = "rental"
+)
+
+// RewardLine is one itemized entry on the cash-out screen. Costs such as
+// rent have a negative Amount.
+type RewardLine struct {
+	Kind   RewardKind
+	Label  string
+	Amount int
+}
+
+// AmountString shows the amount as +$N or -$N
+func (l RewardLine) AmountString() string {
+	if l.Amount < 0 {
+		return fmt.Sprintf("-$%d", -l.Amount)
+	}
+	return fmt.Sprintf("+$%d", l.Amount)
+}
+
+// sumRewards totals the amounts of the given line items
+func sumRewards(lines []RewardLine) int {
+	total := 0
+	for _, line := range lines {
+		total += line.Amount
+	}
+	return total
+}
+
 type BlindDefeatedEvent struct {
 	BlindType      BlindType
-	Score          int
-	Target         int
+	Score          Score
+	Target         Score
 	BaseReward     int
 	BonusReward    int
 	JokerReward    int
+	Interest       int
+	GoldReward     int
+	TagReward      int
+	RentalCost     int
 	TotalReward    int
 	NewMoney       int
 	UnusedHands    int
 	UnusedDiscards int
+	// Lines itemizes TotalReward in the order it is shown when cashing out
+	Lines []RewardLine
 }
 
 func (e BlindDefeatedEvent) EventType() string { return "blind_defeated" }
@@ -127,13 +229,44 @@ func (e AnteCompletedEvent) EventType() string { return "ante_completed" }
 type NewBlindStartedEvent struct {
 	Ante     int
 	Blind    BlindType
-	Target   int
+	Target   Score
 	NewCards []Card
 	Boss     *Boss
 }
 
 func (e NewBlindStartedEvent) EventType() string { return "new_blind_started" }
 
+// BlindPreview describes one blind of the ante on the blind select screen
+type BlindPreview struct {
+	Blind  BlindType
+	Target Score
+	Reward int
+	// Boss is set for the Boss Blind
+	Boss *Boss
+	// Tag is the reward for skipping; nil for the Boss Blind
+	Tag *Tag
+}
+
+// BlindSelectionEvent is emitted before a blind starts, offering to play or
+// skip it
+type BlindSelectionEvent struct {
+	Ante    int
+	Current BlindType
+	Blinds  []BlindPreview
+	Tags    []Tag
+}
+
+func (e BlindSelectionEvent) EventType() string { return "blind_selection" }
+
+// BlindSkippedEvent is emitted when the player skips a blind for its tag
+type BlindSkippedEvent struct {
+	Ante  int
+	Blind BlindType
+	Tag   Tag
+}
+
+func (e BlindSkippedEvent) EventType() string { return "blind_skipped" }
+
 // Shop events
 type ShopOpenedEvent struct {
 	Money      int
@@ -146,6 +279,8 @@ func (e ShopOpenedEvent) EventType() string { return "shop_opened" }
 type ShopItemPurchasedEvent struct {
 	Item           ShopItemData
 	RemainingMoney int
+	// Items is the shop's stock after the purchase
+	Items []ShopItemData
 }
 
 func (e ShopItemPurchasedEvent) EventType() string { return "shop_item_purchased" }
@@ -163,6 +298,40 @@ type ShopClosedEvent struct{}
 
 func (e ShopClosedEvent) EventType() string { return "shop_closed" }
 
+// PackCardData is a card inside an opened booster pack, ready for display.
+// Cards already taken have an empty Name.
+type PackCardData struct {
+	Name        string
+	Description string
+	Type        string
+	Edition     JokerEdition
+}
+
+// PackOpenedEvent shows the cards in a booster pack the player just bought
+type PackOpenedEvent struct {
+	Pack      string
+	Cards     []PackCardData
+	PicksLeft int
+}
+
+func (e PackOpenedEvent) EventType() string { return "pack_opened" }
+
+// PackCardPickedEvent is emitted when the player takes a card from a pack
+type PackCardPickedEvent struct {
+	Card      PackCardData
+	Cards     []PackCardData
+	PicksLeft int
+}
+
+func (e PackCardPickedEvent) EventType() string { return "pack_card_picked" }
+
+// PackClosedEvent is emitted when the player is done with a pack
+type PackClosedEvent struct {
+	Pack string
+}
+
+func (e PackClosedEvent) EventType() string { return "pack_closed" }
+
 // Error/validation events
 type InvalidActionEvent struct {
 	Action string
@@ -185,16 +354,20 @@ type ShopItemData struct {
 	Cost        int
 	Type        string
 	CanAfford   bool
+	Sticker     JokerSticker
+	Edition     JokerEdition
 }
 
 // Helper function to create shop item data from joker
-func NewShopItemData(joker Joker, money int) ShopItemData {
+func NewShopItemData(joker OwnedJoker, money int) ShopItemData {
 	return ShopItemData{
 		Name:        joker.Name,
 		Description: joker.Description,
-		Cost:        joker.Price,
+		Cost:        joker.PurchasePrice,
 		Type:        "joker",
-		CanAfford:   money >= joker.Price,
+		CanAfford:   money >= joker.PurchasePrice,
+		Sticker:     joker.Sticker,
+		Edition:     joker.Edition,
 	}
 }
 
@@ -228,20 +401,6 @@ func (e *SimpleEventEmitter) EmitGameStarted() {
 	e.EmitEvent(GameStartedEvent{})
 }
 
-func (e *SimpleEventEmitter) EmitGameState(ante int, blind BlindType, target, score, hands, discards, money int, jokers []Joker, boss string) {
-	e.EmitEvent(GameStateChangedEvent{
-		Ante:     ante,
-		Blind:    blind,
-		Target:   target,
-		Score:    score,
-		Hands:    hands,
-		Discards: discards,
-		Money:    money,
-		Jokers:   jokers,
-		Boss:     boss,
-	})
-}
-
 func (e *SimpleEventEmitter) EmitCardsDealt(cards []Card, displayMapping []int, sortMode SortMode) {
 	sortModeStr := "rank"
 	if sortMode == SortBySuit {
@@ -32,6 +32,22 @@ func (t *testEventHandler) GetShopAction() (PlayerAction, []string, bool) {
 	return a.action, a.params, false
 }
 
+func (t *testEventHandler) GetEndlessAction() (PlayerAction, []string, bool) {
+	return PlayerActionNone, nil, true
+}
+
+func (t *testEventHandler) GetBlindAction() (PlayerAction, []string, bool) {
+	return PlayerActionSelectBlind, nil, false
+}
+
+func (t *testEventHandler) GetCashOutAction() (PlayerAction, []string, bool) {
+	return PlayerActionCashOut, nil, false
+}
+
+func (t *testEventHandler) GetPackAction() (PlayerAction, []string, bool) {
+	return PlayerActionSkipPack, nil, false
+}
+
 func (t *testEventHandler) Close() {}
 
 // TestHandlePlayAction verifies that playing cards updates score, hand count
@@ -104,9 +120,9 @@ func TestHandleDiscardAction(t *testing.T) {
 	}
 }
 
-// TestShowShopWithItems ensures that purchasing an item deducts money, adds the
+// TestRunShop ensures that purchasing an item deducts money, adds the
 // joker and emits the appropriate events.
-func TestShowShopWithItems(t *testing.T) {
+func TestRunShop(t *testing.T) {
 	handler := &testEventHandler{
 		shopActions: []struct {
 			action PlayerAction
@@ -119,16 +135,14 @@ func TestShowShopWithItems(t *testing.T) {
 
 	g := &Game{
 		money:        10,
-		rerollCost:   5,
-		jokers:       []Joker{},
+		jokers:       []OwnedJoker{},
 		eventEmitter: NewEventEmitter(),
 	}
 	g.eventEmitter.SetEventHandler(handler)
 
-	available := []Joker{{Name: "J1", Price: 5, Description: ""}, {Name: "J2", Price: 6, Description: ""}}
-	shop := []Joker{available[0], available[1]}
+	shop := &Shop{game: g, RerollCost: BaseRerollCost, Items: []ShopItem{{Joker: OwnedJoker{Joker: Joker{Name: "J1", Price: 5}, PurchasePrice: 5}}, {Joker: OwnedJoker{Joker: Joker{Name: "J2", Price: 6}, PurchasePrice: 6}}}}
 
-	g.showShopWithItems(available, shop)
+	g.runShop(shop)
 
 	if g.money != 5 {
 		t.Fatalf("expected money to be 5 after purchase, got %d", g.money)
@@ -163,7 +177,7 @@ func TestHandleSellJokerAction(t *testing.T) {
 	handler := &testEventHandler{}
 	g := &Game{
 		money:        10,
-		jokers:       []Joker{{Name: "J1", Price: 6}, {Name: "J2", Price: 8}},
+		jokers:       []OwnedJoker{{Joker: Joker{Name: "J1", Price: 6}, PurchasePrice: 6}, {Joker: Joker{Name: "J2", Price: 8}, PurchasePrice: 8}},
 		eventEmitter: NewEventEmitter(),
 	}
 	g.eventEmitter.SetEventHandler(handler)
@@ -191,13 +205,26 @@ func TestHandleSellJokerAction(t *testing.T) {
 // TestHandSizeWithJoker verifies that a joker can increase the hand size.
 func TestHandSizeWithJoker(t *testing.T) {
 	g := &Game{
-		jokers: []Joker{{Effects: []JokerEffectConfig{{Effect: AddHandSize, EffectMagnitude: 2}}}},
+		jokers: []OwnedJoker{{Joker: Joker{Effects: []JokerEffectConfig{{Effect: AddHandSize, EffectMagnitude: 2}}}}},
 	}
 	if got := g.handSize(); got != InitialCards+2 {
 		t.Fatalf("expected hand size %d, got %d", InitialCards+2, got)
 	}
 }
 
+// TestNewGameDealsOnBlindStart verifies a new game deals nothing until a
+// blind starts, so the first hand comes from a single deal.
+func TestNewGameDealsOnBlindStart(t *testing.T) {
+	g := NewGame(&testEventHandler{})
+	if len(g.playerCards) != 0 || g.deckIndex != 0 {
+		t.Fatalf("expected no cards dealt before blind select, got %d (deck index %d)", len(g.playerCards), g.deckIndex)
+	}
+	g.startBlind()
+	if len(g.playerCards) != g.handSize() || g.deckIndex != g.handSize() {
+		t.Fatalf("expected %d cards dealt once, got %d (deck index %d)", g.handSize(), len(g.playerCards), g.deckIndex)
+	}
+}
+
 // TestDiscardLimitWithJoker verifies that a joker can increase discard count.
 func TestDiscardLimitWithJoker(t *testing.T) {
 	handler := &testEventHandler{}
@@ -206,7 +233,7 @@ func TestDiscardLimitWithJoker(t *testing.T) {
 		deck:         deck,
 		deckIndex:    InitialCards,
 		playerCards:  deck[:InitialCards],
-		jokers:       []Joker{{Effects: []JokerEffectConfig{{Effect: AddDiscards, EffectMagnitude: 2}}}},
+		jokers:       []OwnedJoker{{Joker: Joker{Effects: []JokerEffectConfig{{Effect: AddDiscards, EffectMagnitude: 2}}}}},
 		eventEmitter: NewEventEmitter(),
 	}
 	g.eventEmitter.SetEventHandler(handler)
@@ -230,10 +257,10 @@ func TestBossNoHeartsDisablesScoring(t *testing.T) {
 	handler := &testEventHandler{}
 	deck := NewDeck()
 	g := &Game{
-		currentBlind:    BossBlind,
-		currentBossRule: BossRuleNoHearts,
-		deck:            deck,
-		deckIndex:       7,
+		currentBlind: BossBlind,
+		currentBoss:  Boss{Name: "The Head", Effects: []BossEffectConfig{{Effect: DebuffSuit, Suit: "Hearts"}}},
+		deck:         deck,
+		deckIndex:    7,
 		playerCards: []Card{
 			{Rank: Ten, Suit: Hearts},
 			{Rank: Two, Suit: Clubs},
@@ -246,6 +273,7 @@ func TestBossNoHeartsDisablesScoring(t *testing.T) {
 		eventEmitter: NewEventEmitter(),
 	}
 	g.eventEmitter.SetEventHandler(handler)
+	g.applyDebuffs()
 
 	g.handlePlayAction([]string{"1"})
 
@@ -254,13 +282,182 @@ func TestBossNoHeartsDisablesScoring(t *testing.T) {
 	}
 }
 
-// TestBossHandSizeReduction verifies boss rule can reduce hand size.
+// TestBossHandSizeReduction verifies a boss effect can reduce hand size.
 func TestBossHandSizeReduction(t *testing.T) {
 	g := &Game{
-		currentBlind:    BossBlind,
-		currentBossRule: BossRuleMinusHand,
+		currentBlind: BossBlind,
+		currentBoss:  Boss{Name: "The Manacle", Effects: []BossEffectConfig{{Effect: ChangeHandSize, Magnitude: -1}}},
 	}
 	if got := g.handSize(); got != InitialCards-1 {
 		t.Fatalf("expected hand size %d, got %d", InitialCards-1, got)
 	}
 }
+
+// TestHandleViewDeckAction verifies the deck view splits drawn and remaining cards.
+func TestHandleViewDeckAction(t *testing.T) {
+	handler := &testEventHandler{}
+	deck := NewDeck()
+	g := &Game{
+		deck:         deck,
+		deckIndex:    InitialCards,
+		playerCards:  deck[:InitialCards],
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handleViewDeckAction()
+
+	var viewed *DeckViewedEvent
+	for _, e := range handler.events {
+		if d, ok := e.(DeckViewedEvent); ok {
+			viewed = &d
+		}
+	}
+	if viewed == nil {
+		t.Fatalf("expected DeckViewedEvent to be emitted")
+	}
+	if len(viewed.Drawn) != InitialCards || len(viewed.Remaining) != len(deck)-InitialCards {
+		t.Fatalf("expected %d drawn and %d remaining, got %d and %d", InitialCards, len(deck)-InitialCards, len(viewed.Drawn), len(viewed.Remaining))
+	}
+	if viewed.Remaining[0] != deck[InitialCards] {
+		t.Fatalf("expected remaining pile to start at deck index, got %v", viewed.Remaining[0])
+	}
+}
+
+// TestInterestOnHeldMoney verifies interest pays $1 per $5 held up to the cap.
+func TestInterestOnHeldMoney(t *testing.T) {
+	cases := []struct {
+		money, bonus, want int
+	}{
+		{money: 0, want: 0},
+		{money: 4, want: 0},
+		{money: 14, want: 2},
+		{money: 100, want: DefaultInterestCap},
+		{money: 100, bonus: 5, want: DefaultInterestCap + 5},
+		{money: -3, want: 0},
+	}
+	for _, c := range cases {
+		g := &Game{money: c.money, vouchers: []Voucher{{Effect: VoucherInterestCap, Amount: c.bonus}}}
+		if got := g.interest(); got != c.want {
+			t.Errorf("interest on $%d (cap +%d) = %d, want %d", c.money, c.bonus, got, c.want)
+		}
+	}
+
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		currentAnte:  1,
+		currentBlind: BigBlind,
+		money:        23,
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+	g.handleBlindCompletion()
+
+	for _, e := range handler.events {
+		if d, ok := e.(BlindDefeatedEvent); ok {
+			if d.Interest != 4 {
+				t.Fatalf("expected $4 interest on $23, got %d", d.Interest)
+			}
+			if d.NewMoney != 23+d.TotalReward {
+				t.Fatalf("expected interest to be included in the total, got %+v", d)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected BlindDefeatedEvent to be emitted")
+}
+
+// cashOutQuitHandler quits at the cash-out screen.
+type cashOutQuitHandler struct {
+	testEventHandler
+}
+
+func (h *cashOutQuitHandler) GetCashOutAction() (PlayerAction, []string, bool) {
+	return PlayerActionNone, nil, true
+}
+
+// TestCashOutLineItems verifies the cash-out itemizes every reward and adds up to the total.
+func TestCashOutLineItems(t *testing.T) {
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		currentAnte:  1,
+		currentBlind: BossBlind,
+		money:        10,
+		handsPlayed:  3,
+		discardsUsed: 2,
+		playerCards:  []Card{{Suit: Spades, Rank: King, Gold: true}, {Suit: Hearts, Rank: Two}},
+		jokers: []OwnedJoker{
+			{Joker: Joker{Name: "The Golden Joker", Effects: []JokerEffectConfig{{Effect: AddMoney, EffectMagnitude: 4}}}},
+			{Joker: Joker{Name: "Landlord"}, Sticker: RentalSticker},
+		},
+		tags:         []Tag{{Type: InvestmentTag}},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	if !g.handleBlindCompletion() {
+		t.Fatalf("expected the rewards to be cashed out")
+	}
+
+	var defeated *BlindDefeatedEvent
+	for _, e := range handler.events {
+		if d, ok := e.(BlindDefeatedEvent); ok {
+			defeated = &d
+		}
+	}
+	if defeated == nil {
+		t.Fatalf("expected BlindDefeatedEvent to be emitted")
+	}
+
+	counts := map[RewardKind]int{}
+	for _, line := range defeated.Lines {
+		counts[line.Kind]++
+	}
+	want := map[RewardKind]int{
+		RewardBlind:         1,
+		RewardUnusedHand:    g.maxHands() - 3,
+		RewardUnusedDiscard: g.maxDiscards() - 2,
+		RewardJoker:         1,
+		RewardInterest:      1,
+		RewardGoldCard:      1,
+		RewardTag:           1,
+		RewardRental:        1,
+	}
+	for kind, n := range want {
+		if counts[kind] != n {
+			t.Errorf("expected %d %s lines, got %d", n, kind, counts[kind])
+		}
+	}
+	if got := sumRewards(defeated.Lines); got != defeated.TotalReward {
+		t.Fatalf("line items add up to %d, want %d", got, defeated.TotalReward)
+	}
+	if defeated.Lines[0].Label != BossBlind.String() || defeated.GoldReward != GoldCardReward {
+		t.Fatalf("unexpected cash-out %+v", defeated)
+	}
+}
+
+// TestQuitAtCashOut verifies quitting at the cash-out skips the shop and resumes at blind select.
+func TestQuitAtCashOut(t *testing.T) {
+	handler := &cashOutQuitHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		currentAnte:  1,
+		currentBlind: SmallBlind,
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	if g.handleBlindCompletion() {
+		t.Fatalf("expected quitting at the cash-out to be reported")
+	}
+	if g.currentBlind != BigBlind || g.phase != PhaseBlindSelect {
+		t.Fatalf("expected to resume at the Big Blind select, got %s in %s", g.currentBlind, g.phase)
+	}
+	for _, e := range handler.events {
+		if _, ok := e.(ShopOpenedEvent); ok {
+			t.Fatalf("expected the shop to be skipped")
+		}
+	}
+}
@@ -56,7 +56,7 @@ func EvaluateHand(hand Hand, levels map[string]int) (HandEvaluator, int, int, in
 			// Calculate total card value
 			totalValue := 0
 			for _, card := range hand.Cards {
-				totalValue += card.Rank.Value()
+				totalValue += card.ScoringValue()
 			}
 
 			level := 1
@@ -77,7 +77,7 @@ func EvaluateHand(hand Hand, levels map[string]int) (HandEvaluator, int, int, in
 	evaluator := &HighCardEvaluator{}
 	totalValue := 0
 	for _, card := range hand.Cards {
-		totalValue += card.Rank.Value()
+		totalValue += card.ScoringValue()
 	}
 	level := 1
 	if levels != nil {
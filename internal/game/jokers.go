@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -20,8 +21,40 @@ const (
 	AddHandSize  JokerEffect = "AddHandSize"
 	AddDiscards  JokerEffect = "AddDiscards"
 	ReplayCard   JokerEffect = "ReplayCard"
+	// GainSellValue raises the joker's own sell value, at the end of each
+	// round unless it has another trigger
+	GainSellValue JokerEffect = "GainSellValue"
+	// GiftSellValue raises every joker's sell value at the end of each round
+	GiftSellValue JokerEffect = "GiftSellValue"
+	// MultiplyChips multiplies the chips scored so far
+	MultiplyChips JokerEffect = "MultiplyChips"
+	// AddMoneyPerCard pays for each matching card played or discarded
+	AddMoneyPerCard JokerEffect = "AddMoneyPerCard"
+	// RetriggerCard makes matching cards fire their effects again, once per
+	// point of magnitude
+	RetriggerCard JokerEffect = "RetriggerCard"
+	// CopyJokerRight copies the effects of the joker to its right (Blueprint)
+	CopyJokerRight JokerEffect = "CopyJokerRight"
+	// CopyJokerLeftmost copies the effects of the leftmost joker (Brainstorm)
+	CopyJokerLeftmost JokerEffect = "CopyJokerLeftmost"
+	// CreateConsumable gives the player consumables if they have room
+	CreateConsumable JokerEffect = "CreateConsumable"
+	// DestroyCard removes matching cards from the deck for the rest of the run
+	DestroyCard JokerEffect = "DestroyCard"
+	// CountAllAsFace makes every card count as a face card (Pareidolia)
+	CountAllAsFace JokerEffect = "CountAllAsFace"
+	// MergeSuits makes the listed suits count as each other (Smeared Joker)
+	MergeSuits JokerEffect = "MergeSuits"
 )
 
+// jokerEffects lists every effect jokers.yaml can use
+var jokerEffects = []JokerEffect{
+	AddMoney, AddChips, AddMult, MultiplyMult, AddHandSize, AddDiscards, ReplayCard,
+	GainSellValue, GiftSellValue, MultiplyChips, AddMoneyPerCard, RetriggerCard,
+	CopyJokerRight, CopyJokerLeftmost, CreateConsumable, DestroyCard,
+	CountAllAsFace, MergeSuits,
+}
+
 // HandMatchingRule represents when a joker effect should trigger
 type HandMatchingRule string
 
@@ -38,7 +71,9 @@ const (
 	ContainsRoyalFlush    HandMatchingRule = "ContainsRoyalFlush"
 )
 
-// CardMatchingRule represents a rule for matching individual cards in a played hand
+// CardMatchingRule represents a rule for matching individual cards in a
+// played hand. Some rules take a parameter after a colon, e.g.
+// "IsSuit:Hearts" or "RankIn:2,3,4,5".
 type CardMatchingRule string
 
 const (
@@ -46,6 +81,21 @@ const (
 	CardIsAce   CardMatchingRule = "IsAce"
 	CardIsSpade CardMatchingRule = "IsSpade"
 	CardIsFace  CardMatchingRule = "IsFace"
+	// CardIsSuit matches one suit, e.g. "IsSuit:Hearts"
+	CardIsSuit CardMatchingRule = "IsSuit"
+	// CardRankIn matches any of a set of ranks, e.g. "RankIn:A,K"
+	CardRankIn CardMatchingRule = "RankIn"
+	// CardIsEven and CardIsOdd match by rank parity; face cards are neither
+	CardIsEven CardMatchingRule = "IsEven"
+	CardIsOdd  CardMatchingRule = "IsOdd"
+	// CardIsNumbered matches 2 through 10
+	CardIsNumbered CardMatchingRule = "IsNumbered"
+	// CardHasEnhancement matches enhanced cards, or one enhancement with a
+	// parameter, e.g. "HasEnhancement:Gold"
+	CardHasEnhancement CardMatchingRule = "HasEnhancement"
+	// CardHasSeal matches sealed cards, or one seal with a parameter, e.g.
+	// "HasSeal:Red"
+	CardHasSeal CardMatchingRule = "HasSeal"
 )
 
 // JokerEffectConfig represents a single effect component of a joker
@@ -54,6 +104,16 @@ type JokerEffectConfig struct {
 	EffectMagnitude  int              `yaml:"effect_magnitude"`
 	HandMatchingRule HandMatchingRule `yaml:"hand_matching_rule"`
 	CardMatchingRule CardMatchingRule `yaml:"card_matching_rule"`
+	// Trigger is when the effect fires; empty uses the effect's default
+	Trigger JokerTrigger `yaml:"trigger"`
+	// Condition and CardCondition add further game state and card checks
+	Condition     *Condition     `yaml:"condition"`
+	CardCondition *CardCondition `yaml:"card_condition"`
+	// Consumable is what CreateConsumable makes: a type such as "Tarot" for
+	// a random card of that type, or a card's name
+	Consumable string `yaml:"consumable"`
+	// Suits are the suits MergeSuits makes count as each other
+	Suits []string `yaml:"suits"`
 }
 
 // JokerConfig represents a joker configuration from YAML
@@ -67,24 +127,123 @@ type JokerConfig struct {
 	EffectMagnitude  int              `yaml:"effect_magnitude"`
 	HandMatchingRule HandMatchingRule `yaml:"hand_matching_rule"`
 	CardMatchingRule CardMatchingRule `yaml:"card_matching_rule"`
+	Trigger          JokerTrigger     `yaml:"trigger"`
+	Condition        *Condition       `yaml:"condition"`
+	CardCondition    *CardCondition   `yaml:"card_condition"`
+	Consumable       string           `yaml:"consumable"`
+	Suits            []string         `yaml:"suits"`
 	// Composite effects
 	Effects []JokerEffectConfig `yaml:"effects"`
+	// Counter makes the joker scale as the run goes on
+	Counter *JokerCounter `yaml:"counter"`
+}
+
+// UnmarshalYAML decodes a joker, naming it in any decoding error such as a
+// misspelled field
+func (c *JokerConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	type plain JokerConfig
+	err := unmarshal((*plain)(c))
+	if terr, ok := err.(*yaml.TypeError); ok {
+		return fmt.Errorf("joker %s: %s", c.Name, strings.Join(terr.Errors, "; "))
+	}
+	if err != nil {
+		return fmt.Errorf("joker %s: %v", c.Name, err)
+	}
+	return nil
 }
 
 // JokersYAML represents the root YAML structure
 type JokersYAML struct {
-	Jokers []JokerConfig `yaml:"jokers"`
+	// Slots is how many jokers the player can hold
+	Slots int `yaml:"slots"`
+	// LegacyScoring sums every joker's chips and mult before applying ×Mult
+	// factors, so joker order doesn't matter
+	LegacyScoring bool          `yaml:"legacy_scoring"`
+	Jokers        []JokerConfig `yaml:"jokers"`
 }
 
-// Joker represents a joker card that modifies gameplay
+// DefaultJokerSlots is how many jokers the player can hold when jokers.yaml
+// doesn't say
+const DefaultJokerSlots = 5
+
+// Joker rarities, from most to least likely to appear in the shop
+const (
+	RarityCommon    = "Common"
+	RarityUncommon  = "Uncommon"
+	RarityRare      = "Rare"
+	RarityLegendary = "Legendary"
+)
+
+// Rarities lists the joker rarities in order
+var Rarities = []string{RarityCommon, RarityUncommon, RarityRare, RarityLegendary}
+
+// Joker represents a joker card that modifies gameplay, as jokers.yaml
+// defines it
 type Joker struct {
 	Name        string
 	Description string
 	Price       int
+	Rarity      string
 	Effects     []JokerEffectConfig
+	// CounterRules make the joker scale
+	CounterRules *JokerCounter
+}
+
+// OwnedJoker is a joker on offer or owned by the player, with its own state
+type OwnedJoker struct {
+	Joker
+	// PurchasePrice is what the joker costs after stickers, editions and
+	// discounts; its sell value is based on it
+	PurchasePrice int
+	// Stake stickers; RoundsLeft counts down for Perishable jokers
+	Sticker    JokerSticker
+	RoundsLeft int
+	// Debuffed jokers have no effect (e.g. an expired Perishable joker)
+	Debuffed bool
+	// Disabled and FaceDown are set by bosses for the current blind only
+	Disabled bool
+	FaceDown bool
+	Edition  JokerEdition
+	// SellBonus is sell value gained while owned, e.g. from Egg or Gift Card
+	SellBonus int
+	// Counter is added to the effects of a joker with CounterRules
+	Counter int
+}
+
+// newOwnedJoker makes a fresh copy of a joker, priced at its base price
+func newOwnedJoker(joker Joker) OwnedJoker {
+	return OwnedJoker{Joker: joker, PurchasePrice: joker.Price}
+}
+
+// SellValue returns what the joker sells for: half its purchase price, or
+// RentalSellValue for a Rental joker, plus any sell value it has gained
+func (j OwnedJoker) SellValue() int {
+	if j.Sticker == RentalSticker {
+		return RentalSellValue + j.SellBonus
+	}
+	return j.PurchasePrice/2 + j.SellBonus
+}
+
+// Active reports whether the joker's effects currently apply
+func (j OwnedJoker) Active() bool {
+	return !j.Debuffed && !j.Disabled
+}
+
+// Face returns the name and description to show for the joker, hiding both
+// while it is face down. Scaling jokers describe their current bonus.
+func (j OwnedJoker) Face() (string, string) {
+	if j.FaceDown {
+		return "Face-down Joker", "???"
+	}
+	if label := j.CounterLabel(); label != "" {
+		return j.Name, fmt.Sprintf("%s (%s)", j.Description, label)
+	}
+	return j.Name, j.Description
 }
 
 var jokerConfigs []JokerConfig
+var jokerSlotCount int
+var legacyScoring bool
 
 // LoadJokerConfigs loads joker configurations from YAML file with fallback to defaults
 func LoadJokerConfigs() error {
@@ -110,22 +269,105 @@ func loadJokersFromYAML() error {
 		return err
 	}
 
-	var jokersYAML JokersYAML
-	err = yaml.Unmarshal(data, &jokersYAML)
+	jokersYAML, err := parseJokersYAML(data)
 	if err != nil {
 		return err
 	}
 
+	jokerConfigs = jokersYAML.Jokers
+	jokerSlotCount = jokersYAML.Slots
+	legacyScoring = jokersYAML.LegacyScoring
+	return nil
+}
+
+// parseJokersYAML decodes and validates joker definitions, rejecting fields
+// it doesn't know so a typo can't silently drop a rule
+func parseJokersYAML(data []byte) (JokersYAML, error) {
+	var jokersYAML JokersYAML
+	if err := yaml.UnmarshalStrict(data, &jokersYAML); err != nil {
+		return JokersYAML{}, err
+	}
+
 	if len(jokersYAML.Jokers) == 0 {
-		return fmt.Errorf("jokers.yaml contains no jokers")
+		return JokersYAML{}, fmt.Errorf("jokers.yaml contains no jokers")
 	}
+	if jokersYAML.Slots < 0 {
+		return JokersYAML{}, fmt.Errorf("slots can't be negative, got %d", jokersYAML.Slots)
+	}
+	for _, config := range jokersYAML.Jokers {
+		if err := validateJokerConfig(config); err != nil {
+			return JokersYAML{}, err
+		}
+	}
+	return jokersYAML, nil
+}
 
-	jokerConfigs = jokersYAML.Jokers
+// validateJokerConfig checks a joker's triggers, conditions and counter
+func validateJokerConfig(config JokerConfig) error {
+	for i, eff := range createJokerFromConfig(config).Effects {
+		prefix := ""
+		if len(config.Effects) > 0 {
+			prefix = fmt.Sprintf("effects[%d].", i)
+		}
+		if err := validateEffect(eff); err != nil {
+			return fmt.Errorf("joker %s: %s%v", config.Name, prefix, err)
+		}
+		if err := validateTrigger(config.Name, eff); err != nil {
+			return err
+		}
+		if eff.trigger() == "" && (eff.Condition != nil || eff.CardCondition != nil) {
+			return fmt.Errorf("joker %s: %scondition: %s always applies and can't have conditions", config.Name, prefix, eff.Effect)
+		}
+		if err := validateRules(eff.rules(), eff.trigger(), prefix); err != nil {
+			return fmt.Errorf("joker %s: %v", config.Name, err)
+		}
+	}
+	return validateCounter(config.Name, config.Counter)
+}
+
+// validateEffect checks the effect is known and has the parameters it needs
+func validateEffect(eff JokerEffectConfig) error {
+	known := false
+	for _, e := range jokerEffects {
+		if e == eff.Effect {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("effect: unknown effect %q", eff.Effect)
+	}
+	switch eff.Effect {
+	case RetriggerCard:
+		if eff.EffectMagnitude < 1 {
+			return fmt.Errorf("effect_magnitude: %s needs a magnitude of at least 1", eff.Effect)
+		}
+	case CreateConsumable:
+		if _, err := consumablePool(eff.Consumable); err != nil {
+			return fmt.Errorf("consumable: %v", err)
+		}
+	}
+	if eff.Consumable != "" && eff.Effect != CreateConsumable {
+		return fmt.Errorf("consumable: only CreateConsumable makes consumables")
+	}
+	if eff.Effect == MergeSuits {
+		if len(eff.Suits) < 2 {
+			return fmt.Errorf("suits: %s needs at least two suits", eff.Effect)
+		}
+		for i, name := range eff.Suits {
+			if _, err := ParseSuit(name); err != nil {
+				return fmt.Errorf("suits[%d]: %v", i, err)
+			}
+		}
+	} else if len(eff.Suits) > 0 {
+		return fmt.Errorf("suits: only MergeSuits takes suits")
+	}
 	return nil
 }
 
 // setDefaultJokerConfigs sets hardcoded default joker configurations
 func setDefaultJokerConfigs() {
+	jokerSlotCount = DefaultJokerSlots
+	legacyScoring = false
 	jokerConfigs = []JokerConfig{
 		{
 			Name:   "The Golden Joker",
@@ -172,7 +414,7 @@ func setDefaultJokerConfigs() {
 		{
 			Name:   "Face Dancer",
 			Value:  7,
-			Rarity: "Common",
+			Rarity: "Uncommon",
 			Effects: []JokerEffectConfig{
 				{
 					Effect:           ReplayCard,
@@ -186,7 +428,7 @@ func setDefaultJokerConfigs() {
 		{
 			Name:   "Multiplier",
 			Value:  8,
-			Rarity: "Common",
+			Rarity: "Rare",
 			Effects: []JokerEffectConfig{
 				{
 					Effect:           MultiplyMult,
@@ -219,6 +461,11 @@ func createJokerFromConfig(config JokerConfig) Joker {
 				EffectMagnitude:  e.EffectMagnitude,
 				HandMatchingRule: handRule,
 				CardMatchingRule: cardRule,
+				Trigger:          e.Trigger,
+				Condition:        e.Condition,
+				CardCondition:    e.CardCondition,
+				Consumable:       e.Consumable,
+				Suits:            e.Suits,
 			})
 		}
 	} else if config.Effect != "" {
@@ -235,14 +482,26 @@ func createJokerFromConfig(config JokerConfig) Joker {
 			EffectMagnitude:  config.EffectMagnitude,
 			HandMatchingRule: handRule,
 			CardMatchingRule: cardRule,
+			Trigger:          config.Trigger,
+			Condition:        config.Condition,
+			CardCondition:    config.CardCondition,
+			Consumable:       config.Consumable,
+			Suits:            config.Suits,
 		})
 	}
 
+	rarity := config.Rarity
+	if rarity == "" {
+		rarity = RarityCommon
+	}
+
 	return Joker{
-		Name:        config.Name,
-		Description: config.Description,
-		Price:       config.Value,
-		Effects:     effects,
+		Name:         config.Name,
+		Description:  config.Description,
+		Price:        config.Value,
+		Rarity:       rarity,
+		Effects:      effects,
+		CounterRules: config.Counter,
 	}
 }
 
@@ -274,15 +533,40 @@ func handMatchesRule(handType string, rule HandMatchingRule) bool {
 	}
 }
 
-// cardMatchesRule checks if a card matches a given card rule
-func cardMatchesRule(card Card, rule CardMatchingRule) bool {
-	switch rule {
+// cardMatchesRule checks if a card matches a given card rule, with suits and
+// face cards read through the jokers' card modifiers
+func cardMatchesRule(card Card, rule CardMatchingRule, m CardModifiers) bool {
+	if card.Debuffed {
+		return false
+	}
+	name, param := rule.split()
+	switch name {
 	case CardIsAce:
 		return card.Rank == Ace
 	case CardIsSpade:
-		return card.Suit == Spades
+		return m.hasSuit(card, Spades)
 	case CardIsFace:
-		return card.Rank == Jack || card.Rank == Queen || card.Rank == King
+		return m.isFace(card)
+	case CardIsSuit:
+		suit, err := ParseSuit(param)
+		return err == nil && m.hasSuit(card, suit)
+	case CardRankIn:
+		for _, rank := range strings.Split(param, ",") {
+			if strings.EqualFold(strings.TrimSpace(rank), card.Rank.String()) {
+				return true
+			}
+		}
+		return false
+	case CardIsEven:
+		return rankParity(card.Rank) == ParityEven
+	case CardIsOdd:
+		return rankParity(card.Rank) == ParityOdd
+	case CardIsNumbered:
+		return isNumbered(card)
+	case CardHasEnhancement:
+		return card.Enhancement() != "" && (param == "" || strings.EqualFold(param, card.Enhancement()))
+	case CardHasSeal:
+		return card.Seal != NoSeal && (param == "" || strings.EqualFold(param, string(card.Seal)))
 	default:
 		return false
 	}
@@ -326,6 +610,21 @@ func containsRoyalFlush(handType string) bool {
 	return handType == "Royal Flush"
 }
 
+// GetJokerSlots returns how many jokers the player can hold before
+// vouchers and Negative jokers
+func GetJokerSlots() int {
+	if jokerSlotCount == 0 {
+		return DefaultJokerSlots
+	}
+	return jokerSlotCount
+}
+
+// LegacyScoring reports whether jokers are scored the old way, with every
+// ×Mult applied after all the +Mult regardless of joker order
+func LegacyScoring() bool {
+	return legacyScoring
+}
+
 // GetAvailableJokers returns all jokers that can be purchased
 func GetAvailableJokers() []Joker {
 	var jokers []Joker
@@ -369,7 +668,7 @@ func GetGoldenJoker() Joker {
 }
 
 // PlayerHasJoker checks if the player already owns a specific joker
-func PlayerHasJoker(playerJokers []Joker, jokerName string) bool {
+func PlayerHasJoker(playerJokers []OwnedJoker, jokerName string) bool {
 	for _, joker := range playerJokers {
 		if joker.Name == jokerName {
 			return true
@@ -379,75 +678,35 @@ func PlayerHasJoker(playerJokers []Joker, jokerName string) bool {
 }
 
 // CalculateJokerRewards calculates total money earned from all jokers at blind end
-func CalculateJokerRewards(jokers []Joker) int {
-	total := 0
-	for _, joker := range jokers {
-		for _, eff := range joker.Effects {
-			if eff.Effect == AddMoney {
-				total += eff.EffectMagnitude
-			}
-		}
-	}
-	return total
+func CalculateJokerRewards(jokers []OwnedJoker) int {
+	return sumRewards(JokerRewardLines(jokers))
+}
+
+// JokerRewardLines itemizes the money each joker pays when a blind is defeated
+func JokerRewardLines(jokers []OwnedJoker) []RewardLine {
+	return jokerMoneyLines(jokers, TriggerBlindEnd, ConditionState{}, nil)
 }
 
 // CalculateJokerHandBonus calculates chips and mult bonus from jokers for a specific hand
 // It returns chip bonuses, additive multiplier bonuses, and multiplier factors.
-func CalculateJokerHandBonus(jokers []Joker, handType string, cards []Card) (int, int, int) {
-	totalChips := 0
-	totalMult := 0
-	multFactor := 1
-
-	for _, joker := range jokers {
-		for _, eff := range joker.Effects {
-			switch eff.Effect {
-			case AddChips, AddMult, MultiplyMult:
-				matches := 0
-				if eff.CardMatchingRule != CardNone {
-					for _, c := range cards {
-						if cardMatchesRule(c, eff.CardMatchingRule) {
-							matches++
-						}
-					}
-				} else if handMatchesRule(handType, eff.HandMatchingRule) {
-					matches = 1
-				}
-				if matches == 0 {
-					continue
-				}
-				bonus := eff.EffectMagnitude * matches
-				switch eff.Effect {
-				case AddChips:
-					totalChips += bonus
-				case AddMult:
-					totalMult += bonus
-				case MultiplyMult:
-					for i := 0; i < matches; i++ {
-						multFactor *= eff.EffectMagnitude
-					}
-				}
-			}
-		}
-	}
-
-	return totalChips, totalMult, multFactor
+// The cards should already include any replays.
+func CalculateJokerHandBonus(jokers []OwnedJoker, handType string, cards []Card) (int, int, Score) {
+	return sumScoringSteps(scoringSteps(jokers, ConditionState{HandType: handType, PlayedCards: len(cards)}, cards, nil, false))
 }
 
-// ApplyReplayCardEffects duplicates cards that match any ReplayCard joker
+// ApplyReplayCardEffects duplicates cards that jokers retrigger when scored
 // and returns the extended card slice along with additional card value
 // contributed by the replays.
-func ApplyReplayCardEffects(jokers []Joker, cards []Card) ([]Card, int) {
+func ApplyReplayCardEffects(jokers []OwnedJoker, state ConditionState, cards []Card) ([]Card, int) {
+	state.Cards = cardModifiers(jokers)
 	var replayed []Card
 	extraValue := 0
-	for _, joker := range jokers {
-		for _, eff := range joker.Effects {
-			if eff.Effect == ReplayCard && eff.CardMatchingRule != CardNone {
-				for _, c := range cards {
-					if cardMatchesRule(c, eff.CardMatchingRule) {
-						replayed = append(replayed, c)
-						extraValue += c.Rank.Value()
-					}
-				}
+	fired := firedEffects(jokers)
+	for _, c := range cards {
+		for _, step := range retriggerSteps(fired, TriggerCardScored, state, c) {
+			for n := 0; n < step.Amount; n++ {
+				replayed = append(replayed, c)
+				extraValue += c.ScoringValue()
 			}
 		}
 	}
@@ -457,7 +716,7 @@ func ApplyReplayCardEffects(jokers []Joker, cards []Card) ([]Card, int) {
 }
 
 // FormatJokersList returns a formatted string of player's jokers
-func FormatJokersList(jokers []Joker) string {
+func FormatJokersList(jokers []OwnedJoker) string {
 	if len(jokers) == 0 {
 		return "No jokers"
 	}
@@ -473,8 +732,8 @@ func FormatJokersList(jokers []Joker) string {
 }
 
 // GetJokersByEffect returns jokers that have a specific effect type
-func GetJokersByEffect(jokers []Joker, effect JokerEffect) []Joker {
-	var filtered []Joker
+func GetJokersByEffect(jokers []OwnedJoker, effect JokerEffect) []OwnedJoker {
+	var filtered []OwnedJoker
 	for _, joker := range jokers {
 		for _, eff := range joker.Effects {
 			if eff.Effect == effect {
@@ -4,8 +4,8 @@ import "testing"
 
 // TestCalculateJokerRewards verifies AddMoney joker rewards at blind end.
 func TestCalculateJokerRewards(t *testing.T) {
-	j := Joker{Effects: []JokerEffectConfig{{Effect: AddMoney, EffectMagnitude: 4}}}
-	if got := CalculateJokerRewards([]Joker{j}); got != 4 {
+	j := OwnedJoker{Joker: Joker{Effects: []JokerEffectConfig{{Effect: AddMoney, EffectMagnitude: 4}}}}
+	if got := CalculateJokerRewards([]OwnedJoker{j}); got != 4 {
 		t.Fatalf("expected 4, got %d", got)
 	}
 }
@@ -13,23 +13,23 @@ func TestCalculateJokerRewards(t *testing.T) {
 // TestCalculateJokerHandBonus verifies chip and multiplier bonuses from jokers.
 func TestCalculateJokerHandBonus(t *testing.T) {
 	chipCfg := JokerConfig{Name: "Chip", Effects: []JokerEffectConfig{{Effect: AddChips, EffectMagnitude: 30, HandMatchingRule: ContainsPair}}}
-	chipJoker := createJokerFromConfig(chipCfg)
+	chipJoker := newOwnedJoker(createJokerFromConfig(chipCfg))
 
 	multCfg := JokerConfig{Name: "Mult", Effects: []JokerEffectConfig{{Effect: AddMult, EffectMagnitude: 5, HandMatchingRule: ContainsPair}}}
-	multJoker := createJokerFromConfig(multCfg)
+	multJoker := newOwnedJoker(createJokerFromConfig(multCfg))
 
-	chips, mult, factor := CalculateJokerHandBonus([]Joker{chipJoker}, "Pair", []Card{})
+	chips, mult, factor := CalculateJokerHandBonus([]OwnedJoker{chipJoker}, "Pair", []Card{})
 	if chips != 30 || mult != 0 || factor != 1 {
 		t.Fatalf("expected 30 chips bonus, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
 
-	chips, mult, factor = CalculateJokerHandBonus([]Joker{multJoker}, "Pair", []Card{})
+	chips, mult, factor = CalculateJokerHandBonus([]OwnedJoker{multJoker}, "Pair", []Card{})
 	if chips != 0 || mult != 5 || factor != 1 {
 		t.Fatalf("expected mult bonus 5, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
 
 	// Non-matching hand should yield no bonus
-	chips, mult, factor = CalculateJokerHandBonus([]Joker{chipJoker}, "High Card", []Card{})
+	chips, mult, factor = CalculateJokerHandBonus([]OwnedJoker{chipJoker}, "High Card", []Card{})
 	if chips != 0 || mult != 0 || factor != 1 {
 		t.Fatalf("expected no bonus for non-matching hand, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
@@ -38,16 +38,16 @@ func TestCalculateJokerHandBonus(t *testing.T) {
 // TestCardMatchingRule verifies bonuses based on individual card matches.
 func TestCardMatchingRule(t *testing.T) {
 	cfg := JokerConfig{Name: "Ace Bonus", Effects: []JokerEffectConfig{{Effect: AddChips, EffectMagnitude: 10, CardMatchingRule: CardIsAce}}}
-	joker := createJokerFromConfig(cfg)
+	joker := newOwnedJoker(createJokerFromConfig(cfg))
 
 	hand := []Card{{Rank: Ace, Suit: Hearts}, {Rank: Ace, Suit: Spades}, {Rank: Two, Suit: Clubs}}
-	chips, mult, factor := CalculateJokerHandBonus([]Joker{joker}, "High Card", hand)
+	chips, mult, factor := CalculateJokerHandBonus([]OwnedJoker{joker}, "High Card", hand)
 	if chips != 20 || mult != 0 || factor != 1 {
 		t.Fatalf("expected 20 chips bonus, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
 
 	hand = []Card{{Rank: Two, Suit: Clubs}}
-	chips, mult, factor = CalculateJokerHandBonus([]Joker{joker}, "High Card", hand)
+	chips, mult, factor = CalculateJokerHandBonus([]OwnedJoker{joker}, "High Card", hand)
 	if chips != 0 || mult != 0 || factor != 1 {
 		t.Fatalf("expected no bonus without matching cards, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
@@ -56,27 +56,67 @@ func TestCardMatchingRule(t *testing.T) {
 // TestReplayFaceCards verifies that ReplayCard jokers process matching cards twice.
 func TestReplayFaceCards(t *testing.T) {
 	replayCfg := JokerConfig{Name: "Face Dancer", Effects: []JokerEffectConfig{{Effect: ReplayCard, CardMatchingRule: CardIsFace}}}
-	replayJoker := createJokerFromConfig(replayCfg)
+	replayJoker := newOwnedJoker(createJokerFromConfig(replayCfg))
 	bonusCfg := JokerConfig{Name: "Face Bonus", Effects: []JokerEffectConfig{{Effect: AddChips, EffectMagnitude: 10, CardMatchingRule: CardIsFace}}}
-	bonusJoker := createJokerFromConfig(bonusCfg)
+	bonusJoker := newOwnedJoker(createJokerFromConfig(bonusCfg))
 
 	cards := []Card{{Rank: Jack, Suit: Hearts}, {Rank: Five, Suit: Clubs}}
 	hand := Hand{Cards: cards}
 	evaluator, _, cardValues, baseScore, baseMult := EvaluateHand(hand, nil)
 
-	cardsForJokers, extraValue := ApplyReplayCardEffects([]Joker{replayJoker, bonusJoker}, cards)
+	cardsForJokers, extraValue := ApplyReplayCardEffects([]OwnedJoker{replayJoker, bonusJoker}, ConditionState{}, cards)
 	cardValues += extraValue
 
-	chips, mult, factor := CalculateJokerHandBonus([]Joker{replayJoker, bonusJoker}, evaluator.Name(), cardsForJokers)
+	chips, mult, factor := CalculateJokerHandBonus([]OwnedJoker{replayJoker, bonusJoker}, evaluator.Name(), cardsForJokers)
 	finalBase := baseScore + chips
-	finalMult := (evaluator.Multiplier() + mult) * factor
-	finalScore := (finalBase + cardValues) * finalMult
+	finalMult := Score(baseMult + mult).Mul(factor)
+	finalScore := Score(finalBase + cardValues).Mul(finalMult)
 
 	if finalScore != 50 {
 		t.Fatalf("expected final score 50, got %d", finalScore)
 	}
 }
 
+// TestReplayWithCardModifiers verifies retriggers read cards through
+// Pareidolia and Smeared Joker, so the replayed cards' chips are added.
+func TestReplayWithCardModifiers(t *testing.T) {
+	pareidolia := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Pareidolia", Effect: CountAllAsFace}))
+	faces := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Face Dancer", Effects: []JokerEffectConfig{{Effect: ReplayCard, CardMatchingRule: CardIsFace}}}))
+	smeared := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Smeared Joker", Effect: MergeSuits, Suits: []string{"Hearts", "Diamonds"}}))
+	hearts := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Heart Dancer", Effects: []JokerEffectConfig{{Effect: ReplayCard, CardMatchingRule: "IsSuit:Hearts"}}}))
+	cards := []Card{{Rank: Five, Suit: Diamonds}}
+
+	if replayed, extra := ApplyReplayCardEffects([]OwnedJoker{pareidolia, faces}, ConditionState{}, cards); len(replayed) != 2 || extra != 5 {
+		t.Errorf("with Pareidolia: got %d cards and %d extra chips, want the 5 replayed for 5 chips", len(replayed), extra)
+	}
+	if replayed, extra := ApplyReplayCardEffects([]OwnedJoker{smeared, hearts}, ConditionState{}, cards); len(replayed) != 2 || extra != 5 {
+		t.Errorf("with Smeared Joker: got %d cards and %d extra chips, want the 5 of Diamonds replayed for 5 chips", len(replayed), extra)
+	}
+}
+
+// TestDebuffedCardsDoNotTriggerJokers verifies debuffed cards score no chips and match no rules.
+func TestDebuffedCardsDoNotTriggerJokers(t *testing.T) {
+	replayCfg := JokerConfig{Name: "Face Dancer", Effects: []JokerEffectConfig{{Effect: ReplayCard, CardMatchingRule: CardIsFace}}}
+	bonusCfg := JokerConfig{Name: "Face Bonus", Effects: []JokerEffectConfig{{Effect: AddChips, EffectMagnitude: 10, CardMatchingRule: CardIsFace}}}
+	jokers := []OwnedJoker{newOwnedJoker(createJokerFromConfig(replayCfg)), newOwnedJoker(createJokerFromConfig(bonusCfg))}
+
+	cards := []Card{{Rank: Jack, Suit: Hearts, Debuffed: true}, {Rank: Five, Suit: Clubs}}
+	_, _, cardValues, _, _ := EvaluateHand(Hand{Cards: cards}, nil)
+	if cardValues != 5 {
+		t.Fatalf("expected debuffed Jack to add no chips, got card values %d", cardValues)
+	}
+
+	cardsForJokers, extraValue := ApplyReplayCardEffects(jokers, ConditionState{}, cards)
+	if extraValue != 0 || len(cardsForJokers) != len(cards) {
+		t.Fatalf("expected no replays for a debuffed card, got extra=%d cards=%d", extraValue, len(cardsForJokers))
+	}
+
+	chips, mult, factor := CalculateJokerHandBonus(jokers, "High Card", cardsForJokers)
+	if chips != 0 || mult != 0 || factor != 1 {
+		t.Fatalf("expected no bonus from a debuffed card, got chips=%d mult=%d factor=%s", chips, mult, factor)
+	}
+}
+
 // TestCompositeJoker verifies that multiple effects on a single joker stack.
 func TestCompositeJoker(t *testing.T) {
 	cfg := JokerConfig{
@@ -86,8 +126,8 @@ func TestCompositeJoker(t *testing.T) {
 			{Effect: AddMult, EffectMagnitude: 2, HandMatchingRule: ContainsPair},
 		},
 	}
-	joker := createJokerFromConfig(cfg)
-	chips, mult, factor := CalculateJokerHandBonus([]Joker{joker}, "Pair", []Card{})
+	joker := newOwnedJoker(createJokerFromConfig(cfg))
+	chips, mult, factor := CalculateJokerHandBonus([]OwnedJoker{joker}, "Pair", []Card{})
 	if chips != 10 || mult != 2 || factor != 1 {
 		t.Fatalf("expected chips=10 mult=2 factor=1, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
@@ -102,13 +142,13 @@ func TestCompositeMoneyAndChips(t *testing.T) {
 			{Effect: AddChips, EffectMagnitude: 10, HandMatchingRule: ContainsPair},
 		},
 	}
-	joker := createJokerFromConfig(cfg)
+	joker := newOwnedJoker(createJokerFromConfig(cfg))
 
-	if reward := CalculateJokerRewards([]Joker{joker}); reward != 3 {
+	if reward := CalculateJokerRewards([]OwnedJoker{joker}); reward != 3 {
 		t.Fatalf("expected money reward 3, got %d", reward)
 	}
 
-	chips, mult, factor := CalculateJokerHandBonus([]Joker{joker}, "Pair", []Card{})
+	chips, mult, factor := CalculateJokerHandBonus([]OwnedJoker{joker}, "Pair", []Card{})
 	if chips != 10 || mult != 0 || factor != 1 {
 		t.Fatalf("expected chips=10 mult=0 factor=1, got chips=%d mult=%d factor=%d", chips, mult, factor)
 	}
@@ -117,16 +157,43 @@ func TestCompositeMoneyAndChips(t *testing.T) {
 // TestMultiplyMult verifies jokers that multiply the multiplier.
 func TestMultiplyMult(t *testing.T) {
 	cfg := JokerConfig{Name: "Doubler", Effects: []JokerEffectConfig{{Effect: MultiplyMult, EffectMagnitude: 2, HandMatchingRule: ContainsPair}}}
-	joker := createJokerFromConfig(cfg)
+	joker := newOwnedJoker(createJokerFromConfig(cfg))
 
-	_, mult, factor := CalculateJokerHandBonus([]Joker{joker}, "Pair", []Card{})
+	_, mult, factor := CalculateJokerHandBonus([]OwnedJoker{joker}, "Pair", []Card{})
 	if mult != 0 || factor != 2 {
 		t.Fatalf("expected multiplier factor=2, got mult=%d factor=%d", mult, factor)
 	}
 
 	// Non-matching hand should not multiply
-	_, mult, factor = CalculateJokerHandBonus([]Joker{joker}, "High Card", []Card{})
+	_, mult, factor = CalculateJokerHandBonus([]OwnedJoker{joker}, "High Card", []Card{})
 	if mult != 0 || factor != 1 {
 		t.Fatalf("expected no effect for non-matching hand, got mult=%d factor=%d", mult, factor)
 	}
 }
+
+// TestEndRoundSellValues verifies Egg and Gift Card raise sell values each
+// round, and that selling pays the raised value.
+func TestEndRoundSellValues(t *testing.T) {
+	egg := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Egg", Value: 4, Effect: GainSellValue, EffectMagnitude: 3}))
+	gift := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Gift Card", Value: 6, Effect: GiftSellValue, EffectMagnitude: 1}))
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{egg, gift, {Joker: Joker{Name: "Plain", Price: 5}, PurchasePrice: 5}}
+
+	g.fireJokers(TriggerBlindEnd, ConditionState{}, nil)
+	g.fireJokers(TriggerBlindEnd, ConditionState{}, nil)
+	for i, want := range []int{2 + 8, 3 + 2, 2 + 2} {
+		if got := g.jokers[i].SellValue(); got != want {
+			t.Errorf("%s sells for $%d, want $%d", g.jokers[i].Name, got, want)
+		}
+	}
+
+	g.jokers[0].Debuffed = true
+	g.fireJokers(TriggerBlindEnd, ConditionState{}, nil)
+	if got := g.jokers[0].SellValue(); got != 11 {
+		t.Errorf("expected a debuffed Egg to gain only Gift Card's $1, sells for $%d", got)
+	}
+
+	if _, ok := g.sellJoker(1); !ok || g.money != 20+11 {
+		t.Fatalf("expected Egg to sell for $11, money=%d", g.money)
+	}
+}
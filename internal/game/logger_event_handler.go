@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 )
 
@@ -40,6 +41,8 @@ func (h *LoggerEventHandler) HandleEvent(event Event) {
 		h.handleCardsDiscarded(e)
 	case CardsResortedEvent:
 		h.handleCardsResorted(e)
+	case DeckViewedEvent:
+		h.handleDeckViewed(e)
 	case BlindDefeatedEvent:
 		h.handleBlindDefeated(e)
 	case AnteCompletedEvent:
@@ -54,6 +57,12 @@ func (h *LoggerEventHandler) HandleEvent(event Event) {
 		h.handleShopRerolled(e)
 	case ShopClosedEvent:
 		h.handleShopClosed()
+	case PackOpenedEvent:
+		h.handlePackOpened(e)
+	case PackCardPickedEvent:
+		h.handlePackCardPicked(e)
+	case PackClosedEvent:
+		h.handlePackClosed(e)
 	case InvalidActionEvent:
 		h.handleInvalidAction(e)
 	case MessageEvent:
@@ -62,6 +71,12 @@ func (h *LoggerEventHandler) HandleEvent(event Event) {
 		h.handleGameOver(e)
 	case VictoryEvent:
 		h.handleVictory()
+	case EndlessModeStartedEvent:
+		h.handleEndlessModeStarted(e)
+	case BlindSelectionEvent:
+		h.handleBlindSelection(e)
+	case BlindSkippedEvent:
+		h.handleBlindSkipped(e)
 	}
 }
 
@@ -75,49 +90,108 @@ func (h *LoggerEventHandler) handleGameStarted() {
 
 func (h *LoggerEventHandler) handleGameStateChanged(e GameStateChangedEvent) {
 	blindName := e.Blind.String()
+	if e.Showdown {
+		blindName = "Showdown " + blindName
+	}
 	if e.Blind == BossBlind && e.Boss != "" {
 		blindName = fmt.Sprintf("%s: %s", blindName, e.Boss)
 	}
-	fmt.Printf("🎯 Ante %d - %s | Target: %d | Current Score: %d\n", e.Ante, blindName, e.Target, e.Score)
+	fmt.Printf("🎯 Ante %d - %s | Target: %s | Current Score: %s\n", e.Ante, blindName, e.Target, e.Score)
 	fmt.Printf("🎴 Hands Left: %d | 🗑️ Discards Left: %d | 💰 Money: $%d\n", e.Hands, e.Discards, e.Money)
 
 	if len(e.Jokers) > 0 {
-		fmt.Print("🃏 Jokers: ")
+		if e.JokerSlots > 0 {
+			fmt.Printf("🃏 Jokers (%d/%d): ", JokerSlotsUsed(e.Jokers), e.JokerSlots)
+		} else {
+			fmt.Print("🃏 Jokers: ")
+		}
 		for i, joker := range e.Jokers {
 			if i > 0 {
 				fmt.Print(", ")
 			}
-			fmt.Printf("%s (%s)", joker.Name, joker.Description)
+			name, description := joker.Face()
+			fmt.Printf("%s (%s)", name, description)
+			if label := joker.StickerLabel(); label != "" {
+				fmt.Printf(" %s", label)
+			}
+		}
+		fmt.Println()
+	}
+	if len(e.Consumables) > 0 {
+		fmt.Print("🪐 Consumables: ")
+		for i, c := range e.Consumables {
+			if i > 0 {
+				fmt.Print(", ")
+			}
+			fmt.Printf("%d. %s (%s)", i+1, c.Name, c.Description())
 		}
 		fmt.Println()
 	}
+	if len(e.Vouchers) > 0 {
+		var names []string
+		for _, v := range e.Vouchers {
+			names = append(names, v.Name)
+		}
+		fmt.Printf("🎟️ Vouchers: %s\n", strings.Join(names, ", "))
+	}
 	fmt.Println()
 }
 
 func (h *LoggerEventHandler) handleCardsDealt(e CardsDealtEvent) {
 	fmt.Printf("🃏 Your Hand (%d cards - sorted by %s):\n", len(e.Cards), e.SortMode)
 
+	debuffed, forced := false, false
 	for i, card := range e.Cards {
-		fmt.Printf("%d: %s ", i+1, card.String())
+		fmt.Printf("%d: %s ", i+1, consoleCard(card))
 		if (i+1)%8 == 0 {
 			fmt.Println()
 		}
+		debuffed = debuffed || card.Debuffed
+		forced = forced || card.Forced
 	}
 	fmt.Println()
+	if debuffed {
+		fmt.Println("✗ = debuffed (scores no chips and triggers no jokers)")
+	}
+	if forced {
+		fmt.Println("! = forced (always played or discarded with your selection)")
+	}
+}
+
+// consoleCard renders a card, marking debuffed cards with ✗ and forced
+// cards with !
+func consoleCard(card Card) string {
+	s := card.String()
+	if card.Debuffed {
+		s += "✗"
+	}
+	if card.Forced {
+		s += "!"
+	}
+	return s
 }
 
 func (h *LoggerEventHandler) handleHandPlayed(e HandPlayedEvent) {
 	// Build hand string
 	var handStr []string
 	for _, card := range e.SelectedCards {
-		handStr = append(handStr, card.String())
+		handStr = append(handStr, consoleCard(card))
 	}
 
 	fmt.Println()
 	fmt.Printf("Your hand: %s\n", strings.Join(handStr, " "))
 	fmt.Printf("Hand type: %s\n", e.HandType)
 
-	if e.JokerChips > 0 || e.JokerMult > 0 || e.JokerMultFactor > 1 {
+	if e.NotAllowed != "" {
+		fmt.Printf("🚫 Not allowed: %s. The hand scores nothing.\n", e.NotAllowed)
+		fmt.Printf("💰 Total Score: %s\n", e.NewTotalScore)
+		fmt.Println(strings.Repeat("-", 50))
+		fmt.Println()
+		return
+	}
+
+	timesChips := hasStepEffect(e.Steps, MultiplyChips)
+	if e.JokerChips > 0 || e.JokerMult > 0 || e.JokerMultFactor > 1 || timesChips {
 		fmt.Printf("Base Score: %d", e.BaseScore)
 		if e.JokerChips > 0 {
 			fmt.Printf(" + %d Joker Chips", e.JokerChips)
@@ -127,20 +201,29 @@ func (h *LoggerEventHandler) handleHandPlayed(e HandPlayedEvent) {
 			fmt.Printf(" + %d Joker Mult", e.JokerMult)
 		}
 		if e.JokerMultFactor > 1 {
-			fmt.Printf(" × %d Joker Mult", e.JokerMultFactor)
+			fmt.Printf(" × %s Joker Mult", e.JokerMultFactor)
 		}
 		fmt.Println()
-		if e.JokerMultFactor > 1 {
-			fmt.Printf("Final Score: (%d + %d) × (%d + %d) × %d = %d points\n", e.BaseScore+e.JokerChips, e.CardValues, e.Multiplier, e.JokerMult, e.JokerMultFactor, e.FinalScore)
+		if timesChips {
+			if !LegacyScoring() {
+				fmt.Printf("Chips: %s = %s\n", scoringTrail(e.BaseScore+e.CardValues, e.Steps, AddChips, MultiplyChips), e.FinalChips)
+				fmt.Printf("Mult: %s = %s\n", scoringTrail(e.Multiplier, e.Steps, AddMult, MultiplyMult), e.FinalMult)
+			}
+			fmt.Printf("Final Score: %s × %s = %s points\n", e.FinalChips, e.FinalMult, e.FinalScore)
+		} else if e.JokerMultFactor > 1 && !LegacyScoring() {
+			fmt.Printf("Mult: %s = %s\n", scoringTrail(e.Multiplier, e.Steps, AddMult, MultiplyMult), e.FinalMult)
+			fmt.Printf("Final Score: (%d + %d) × %s = %s points\n", e.BaseScore+e.JokerChips, e.CardValues, e.FinalMult, e.FinalScore)
+		} else if e.JokerMultFactor > 1 {
+			fmt.Printf("Final Score: (%d + %d) × (%d + %d) × %s = %s points\n", e.BaseScore+e.JokerChips, e.CardValues, e.Multiplier, e.JokerMult, e.JokerMultFactor, e.FinalScore)
 		} else {
-			fmt.Printf("Final Score: (%d + %d) × %d = %d points\n", e.BaseScore+e.JokerChips, e.CardValues, e.Multiplier+e.JokerMult, e.FinalScore)
+			fmt.Printf("Final Score: (%d + %d) × %d = %s points\n", e.BaseScore+e.JokerChips, e.CardValues, e.Multiplier+e.JokerMult, e.FinalScore)
 		}
 	} else {
 		fmt.Printf("Base Score: %d | Card Values: %d | Mult: %dx\n", e.BaseScore, e.CardValues, e.Multiplier)
-		fmt.Printf("Final Score: (%d + %d) × %d = %d points\n", e.BaseScore, e.CardValues, e.Multiplier, e.FinalScore)
+		fmt.Printf("Final Score: (%d + %d) × %d = %s points\n", e.BaseScore, e.CardValues, e.Multiplier, e.FinalScore)
 	}
 
-	fmt.Printf("💰 Total Score: %d\n", e.NewTotalScore)
+	fmt.Printf("💰 Total Score: %s\n", e.NewTotalScore)
 	fmt.Println(strings.Repeat("-", 50))
 	fmt.Println()
 }
@@ -170,38 +253,106 @@ func (h *LoggerEventHandler) handleCardsResorted(e CardsResortedEvent) {
 	fmt.Println()
 }
 
+func (h *LoggerEventHandler) handleDeckViewed(e DeckViewedEvent) {
+	total := len(e.Drawn) + len(e.Remaining)
+	fmt.Printf("🂠 Deck: %d of %d cards remaining (drawn cards in parentheses)\n", len(e.Remaining), total)
+
+	drawnBySuit := make(map[Suit][]Card)
+	remainingBySuit := make(map[Suit][]Card)
+	for _, card := range e.Drawn {
+		drawnBySuit[card.Suit] = append(drawnBySuit[card.Suit], card)
+	}
+	for _, card := range e.Remaining {
+		remainingBySuit[card.Suit] = append(remainingBySuit[card.Suit], card)
+	}
+
+	for suit := Hearts; suit <= Spades; suit++ {
+		var entries []string
+		for rank := Ace; rank <= King; rank++ {
+			for _, card := range remainingBySuit[suit] {
+				if card.Rank == rank {
+					entries = append(entries, rank.String())
+				}
+			}
+			for _, card := range drawnBySuit[suit] {
+				if card.Rank == rank {
+					entries = append(entries, "("+rank.String()+")")
+				}
+			}
+		}
+		fmt.Printf("   %s: %s\n", suit, strings.Join(entries, " "))
+	}
+
+	suitCounts := CountBySuit(e.Remaining)
+	fmt.Print("   Remaining by suit:")
+	for suit := Hearts; suit <= Spades; suit++ {
+		fmt.Printf(" %s %d", suit, suitCounts[suit])
+	}
+	fmt.Println()
+
+	rankCounts := CountByRank(e.Remaining)
+	fmt.Print("   Remaining by rank:")
+	for rank := Ace; rank <= King; rank++ {
+		fmt.Printf(" %s:%d", rank, rankCounts[rank])
+	}
+	fmt.Println()
+	fmt.Println()
+
+	// Hold the deck on screen until the player is done, or the next state
+	// dump scrolls it away
+	fmt.Print("Press Enter to return to your hand: ")
+	h.scanner.Scan()
+	fmt.Println()
+}
+
 func (h *LoggerEventHandler) handleBlindDefeated(e BlindDefeatedEvent) {
 	// Different celebrations for different blind types
 	switch e.BlindType {
 	case SmallBlind:
 		fmt.Println(strings.Repeat("=", 60))
 		fmt.Println("🔸 SMALL BLIND DEFEATED! 🔸")
-		fmt.Printf("    ✨ Score: %d/%d ✨\n", e.Score, e.Target)
+		fmt.Printf("    ✨ Score: %s/%s ✨\n", e.Score, e.Target)
 		fmt.Println("   🎯 Advancing to Big Blind...")
 		fmt.Println(strings.Repeat("=", 60))
 	case BigBlind:
 		fmt.Println(strings.Repeat("=", 60))
 		fmt.Println("🔶 BIG BLIND CRUSHED! 🔶")
-		fmt.Printf("    ⚡ Score: %d/%d ⚡\n", e.Score, e.Target)
+		fmt.Printf("    ⚡ Score: %s/%s ⚡\n", e.Score, e.Target)
 		fmt.Println("   💀 Prepare for the Boss Blind...")
 		fmt.Println(strings.Repeat("=", 60))
 	case BossBlind:
 		fmt.Println(strings.Repeat("🎆", 15))
 		fmt.Println("💀 BOSS BLIND ANNIHILATED! 💀")
-		fmt.Printf("    🔥 EPIC SCORE: %d/%d 🔥\n", e.Score, e.Target)
+		fmt.Printf("    🔥 EPIC SCORE: %s/%s 🔥\n", e.Score, e.Target)
 		fmt.Println(strings.Repeat("🎆", 15))
 	}
 
-	fmt.Println("💰 REWARD BREAKDOWN:")
-	fmt.Printf("   Base: $%d", e.BaseReward)
-	if e.BonusReward > 0 {
-		fmt.Printf(" + Unused: $%d (%d hands + %d discards)", e.BonusReward, e.UnusedHands, e.UnusedDiscards)
+	fmt.Println("💰 CASH OUT:")
+	for _, line := range e.Lines {
+		fmt.Printf("   %-36s %s\n", line.Label, line.AmountString())
 	}
-	if e.JokerReward > 0 {
-		fmt.Printf(" + Jokers: $%d", e.JokerReward)
+	fmt.Printf("   %-36s $%d\n", "Total", e.TotalReward)
+	fmt.Printf("   💰 Your Money: $%d\n", e.NewMoney)
+}
+
+// GetCashOutAction waits for the player to collect the blind's rewards
+func (h *LoggerEventHandler) GetCashOutAction() (PlayerAction, []string, bool) {
+	fmt.Print("Press Enter to cash out (or 'q' to quit): ")
+
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			fmt.Println("Error reading input:", err)
+		}
+		return PlayerActionNone, nil, true
 	}
-	fmt.Printf("\n   💰 Total Earned: $%d | Your Money: $%d\n", e.TotalReward, e.NewMoney)
 	fmt.Println()
+
+	switch strings.ToLower(strings.TrimSpace(h.scanner.Text())) {
+	case "q", "quit":
+		return PlayerActionNone, nil, true
+	default:
+		return PlayerActionCashOut, nil, false
+	}
 }
 
 func (h *LoggerEventHandler) handleAnteCompleted(e AnteCompletedEvent) {
@@ -224,9 +375,12 @@ func (h *LoggerEventHandler) handleNewBlindStarted(e NewBlindStartedEvent) {
 	}
 
 	fmt.Printf("%s NOW ENTERING: %s (Ante %d) %s\n", blindEmoji, e.Blind, e.Ante, blindEmoji)
-	fmt.Printf("🎯 NEW TARGET: %d points\n", e.Target)
+	fmt.Printf("🎯 NEW TARGET: %s points\n", e.Target)
 	if e.Blind == BossBlind && e.Boss != nil {
-		fmt.Printf("👑 Boss: %s - %s\n", e.Boss.Name, e.Boss.Effect)
+		if e.Boss.Final {
+			fmt.Println("🔥 SHOWDOWN! 🔥")
+		}
+		fmt.Printf("👑 Boss: %s - %s\n", e.Boss.Name, e.Boss.Describe())
 	}
 	fmt.Println("🃏 Fresh hand dealt!")
 	fmt.Println(strings.Repeat("-", 40))
@@ -240,19 +394,12 @@ func (h *LoggerEventHandler) handleShopOpened(e ShopOpenedEvent) {
 	fmt.Println()
 
 	fmt.Println("Available items:")
-	for i, item := range e.Items {
-		affordText := ""
-		if !item.CanAfford {
-			affordText = " (can't afford)"
-		}
-		fmt.Printf("%d. %s - $%d%s\n", i+1, item.Name, item.Cost, affordText)
-		fmt.Printf("   %s\n", item.Description)
-		fmt.Println()
-	}
+	printShopItems(e.Items)
 
 	fmt.Println("Commands:")
 	fmt.Println("• buy <number> - Purchase an item")
 	fmt.Println("• reroll - Reroll the shop items")
+	fmt.Println("• use <number> - Use a consumable")
 	fmt.Println("• exit/q - Leave the shop")
 }
 
@@ -260,6 +407,9 @@ func (h *LoggerEventHandler) handleShopItemPurchased(e ShopItemPurchasedEvent) {
 	fmt.Printf("✨ Purchased %s! ✨\n", e.Item.Name)
 	fmt.Printf("💰 Remaining money: $%d\n", e.RemainingMoney)
 	fmt.Println()
+
+	fmt.Println("Available items:")
+	printShopItems(e.Items)
 }
 
 func (h *LoggerEventHandler) handleShopRerolled(e ShopRerolledEvent) {
@@ -268,12 +418,36 @@ func (h *LoggerEventHandler) handleShopRerolled(e ShopRerolledEvent) {
 	fmt.Println()
 
 	fmt.Println("New items:")
-	for i, item := range e.NewItems {
+	printShopItems(e.NewItems)
+}
+
+// printShopItems lists the shop's items with their prices
+func printShopItems(items []ShopItemData) {
+	for i, item := range items {
+		if item.Name == "" {
+			fmt.Printf("%d. (sold)\n", i+1)
+			fmt.Println()
+			continue
+		}
 		affordText := ""
 		if !item.CanAfford {
 			affordText = " (can't afford)"
 		}
-		fmt.Printf("%d. %s - $%d%s\n", i+1, item.Name, item.Cost, affordText)
+		stickerText := ""
+		if item.Edition != NoEdition {
+			stickerText = fmt.Sprintf(" [%s]", item.Edition)
+		}
+		if item.Sticker != NoSticker {
+			stickerText += fmt.Sprintf(" [%s]", item.Sticker)
+		}
+		switch item.Type {
+		case "", "joker":
+		case "pack", "voucher":
+			stickerText += fmt.Sprintf(" (%s)", item.Type)
+		default:
+			stickerText += fmt.Sprintf(" (%s card)", item.Type)
+		}
+		fmt.Printf("%d. %s%s - $%d%s\n", i+1, item.Name, stickerText, item.Cost, affordText)
 		fmt.Printf("   %s\n", item.Description)
 		fmt.Println()
 	}
@@ -284,6 +458,40 @@ func (h *LoggerEventHandler) handleShopClosed() {
 	fmt.Println()
 }
 
+func (h *LoggerEventHandler) handlePackOpened(e PackOpenedEvent) {
+	fmt.Printf("📦 Opened %s! Choose %d:\n", e.Pack, e.PicksLeft)
+	printPackCards(e.Cards)
+}
+
+func (h *LoggerEventHandler) handlePackCardPicked(e PackCardPickedEvent) {
+	fmt.Printf("✨ Took %s!\n", e.Card.Name)
+	if e.PicksLeft > 0 {
+		fmt.Printf("📦 Choose %d more:\n", e.PicksLeft)
+		printPackCards(e.Cards)
+	}
+}
+
+func (h *LoggerEventHandler) handlePackClosed(e PackClosedEvent) {
+	fmt.Printf("📦 Done with %s. Back to the shop.\n", e.Pack)
+	fmt.Println()
+}
+
+// printPackCards lists the cards left in an opened booster pack
+func printPackCards(cards []PackCardData) {
+	for i, card := range cards {
+		if card.Name == "" {
+			continue
+		}
+		name := card.Name
+		if card.Edition != NoEdition {
+			name = fmt.Sprintf("%s [%s]", name, card.Edition)
+		}
+		fmt.Printf("%d. %s (%s)\n", i+1, name, card.Type)
+		fmt.Printf("   %s\n", card.Description)
+	}
+	fmt.Println()
+}
+
 func (h *LoggerEventHandler) handleInvalidAction(e InvalidActionEvent) {
 	fmt.Printf("❌ %s\n", e.Reason)
 }
@@ -306,7 +514,7 @@ func (h *LoggerEventHandler) handleMessage(e MessageEvent) {
 func (h *LoggerEventHandler) handleGameOver(e GameOverEvent) {
 	fmt.Println(strings.Repeat("=", 50))
 	fmt.Println("💀 DEFEAT! You failed to beat the blind.")
-	fmt.Printf("Final Score: %d/%d (Ante %d)\n", e.FinalScore, e.Target, e.Ante)
+	fmt.Printf("Final Score: %s/%s (Ante %d)\n", e.FinalScore, e.Target, e.Ante)
 	fmt.Println("Better luck next time!")
 	fmt.Println(strings.Repeat("=", 50))
 }
@@ -319,12 +527,98 @@ func (h *LoggerEventHandler) handleVictory() {
 	fmt.Println(strings.Repeat("=", 60))
 }
 
+func (h *LoggerEventHandler) handleEndlessModeStarted(e EndlessModeStartedEvent) {
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Printf("♾️  ENDLESS MODE! Ante %d and beyond, targets keep climbing.\n", e.Ante)
+	fmt.Println(strings.Repeat("=", 60))
+}
+
+func (h *LoggerEventHandler) handleBlindSelection(e BlindSelectionEvent) {
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("🎲 ANTE %d - CHOOSE YOUR NEXT BLIND\n", e.Ante)
+	for _, b := range e.Blinds {
+		marker := "  "
+		if b.Blind == e.Current {
+			marker = "▶ "
+		} else if b.Blind < e.Current {
+			marker = "✓ "
+		}
+		line := fmt.Sprintf("%s%s - Target: %s | Reward: $%d", marker, b.Blind, b.Target, b.Reward)
+		if b.Boss != nil {
+			line += fmt.Sprintf(" | 👑 %s: %s", b.Boss.Name, b.Boss.Describe())
+		}
+		if b.Tag != nil {
+			line += fmt.Sprintf(" | Skip for 🏷️  %s: %s", b.Tag.Name(), b.Tag.Description())
+		}
+		fmt.Println(line)
+	}
+	if len(e.Tags) > 0 {
+		var names []string
+		for _, t := range e.Tags {
+			names = append(names, t.Name())
+		}
+		fmt.Printf("🏷️  Your tags: %s\n", strings.Join(names, ", "))
+	}
+	fmt.Println()
+}
+
+func (h *LoggerEventHandler) handleBlindSkipped(e BlindSkippedEvent) {
+	fmt.Printf("⏭️  Skipped the %s and earned the %s!\n", e.Blind, e.Tag.Name())
+	fmt.Println()
+}
+
+// GetBlindAction asks whether to play or skip the blind on offer
+func (h *LoggerEventHandler) GetBlindAction() (PlayerAction, []string, bool) {
+	fmt.Print("(p)lay this blind, (s)kip it for its tag, or (q)uit: ")
+
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			fmt.Println("Error reading input:", err)
+		}
+		return PlayerActionNone, nil, true
+	}
+
+	switch strings.ToLower(strings.TrimSpace(h.scanner.Text())) {
+	case "p", "play", "":
+		return PlayerActionSelectBlind, nil, false
+	case "s", "skip":
+		return PlayerActionSkipBlind, nil, false
+	case "q", "quit":
+		return PlayerActionNone, nil, true
+	default:
+		fmt.Println("Please enter 'p', 's' or 'q'")
+		return PlayerActionNone, nil, false
+	}
+}
+
+// GetEndlessAction asks whether to keep playing past the final ante
+func (h *LoggerEventHandler) GetEndlessAction() (PlayerAction, []string, bool) {
+	fmt.Print("Continue into endless mode? (y/n): ")
+
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			fmt.Println("Error reading input:", err)
+		}
+		return PlayerActionNone, nil, true
+	}
+
+	switch strings.ToLower(strings.TrimSpace(h.scanner.Text())) {
+	case "y", "yes":
+		return PlayerActionContinueEndless, nil, false
+	case "n", "no", "q", "quit":
+		return PlayerActionNone, nil, true
+	default:
+		fmt.Println("Please enter 'y' or 'n'")
+		return PlayerActionNone, nil, false
+	}
+}
+
 // GetPlayerAction gets input for player actions
 func (h *LoggerEventHandler) GetPlayerAction(canDiscard bool) (PlayerAction, []string, bool) {
 	if canDiscard {
-		fmt.Print("(p)lay <cards>, (d)iscard <cards>, (r)esort, or (q)uit: ")
+		fmt.Print("(p)lay <cards>, (d)iscard <cards>, (r)esort, (u)se <consumable>, deck, or (q)uit: ")
 	} else {
-		fmt.Print("(p)lay <cards>, (r)esort, or (q)uit: ")
+		fmt.Print("(p)lay <cards>, (r)esort, (u)se <consumable>, deck, or (q)uit: ")
 	}
 
 	if !h.scanner.Scan() {
@@ -360,6 +654,10 @@ func (h *LoggerEventHandler) GetPlayerAction(canDiscard bool) (PlayerAction, []s
 		selectedAction = PlayerActionDiscard
 	} else if actionChar == "r" {
 		selectedAction = PlayerActionResort
+	} else if actionChar == "deck" {
+		selectedAction = PlayerActionViewDeck
+	} else if actionChar == "u" || actionChar == "use" {
+		selectedAction = PlayerActionUseConsumable
 	} else if actionChar == "q" {
 		return PlayerActionNone, nil, true
 	}
@@ -374,7 +672,7 @@ func (h *LoggerEventHandler) GetPlayerAction(canDiscard bool) (PlayerAction, []s
 
 // GetShopAction gets input for shop actions
 func (h *LoggerEventHandler) GetShopAction() (PlayerAction, []string, bool) {
-	fmt.Print("Shop action (buy <number>, reroll, exit/q): ")
+	fmt.Print("Shop action (buy <number>, reroll, use <number>, exit/q): ")
 
 	if !h.scanner.Scan() {
 		if err := h.scanner.Err(); err != nil {
@@ -400,6 +698,8 @@ func (h *LoggerEventHandler) GetShopAction() (PlayerAction, []string, bool) {
 		action = PlayerActionBuy
 	case "r", "reroll":
 		action = PlayerActionReroll
+	case "u", "use":
+		action = PlayerActionUseConsumable
 	default:
 		fmt.Println("No action recognized", input)
 		action = PlayerActionNone
@@ -408,7 +708,68 @@ func (h *LoggerEventHandler) GetShopAction() (PlayerAction, []string, bool) {
 	return action, params, false
 }
 
+// GetPackAction asks which card to take from an opened booster pack
+func (h *LoggerEventHandler) GetPackAction() (PlayerAction, []string, bool) {
+	fmt.Print("Pack action (pick <number>, skip, q): ")
+
+	if !h.scanner.Scan() {
+		if err := h.scanner.Err(); err != nil {
+			fmt.Println("Error reading input:", err)
+		}
+		return PlayerActionNone, nil, true
+	}
+
+	parts := strings.Fields(strings.ToLower(strings.TrimSpace(h.scanner.Text())))
+	if len(parts) == 0 {
+		fmt.Println("Please enter 'pick <number>', 'skip' or 'q'")
+		return PlayerActionNone, nil, false
+	}
+
+	switch parts[0] {
+	case "p", "pick":
+		return PlayerActionPickCard, parts[1:], false
+	case "s", "skip":
+		return PlayerActionSkipPack, nil, false
+	case "q", "quit":
+		return PlayerActionNone, nil, true
+	default:
+		// A bare number picks that card
+		if len(parts) == 1 {
+			if _, err := strconv.Atoi(parts[0]); err == nil {
+				return PlayerActionPickCard, parts, false
+			}
+		}
+		fmt.Println("Please enter 'pick <number>', 'skip' or 'q'")
+		return PlayerActionNone, nil, false
+	}
+}
+
 // Close cleans up resources
 func (h *LoggerEventHandler) Close() {
 	// Nothing to clean up for console mode
 }
+
+// scoringTrail shows how jokers changed the chips or mult in order, e.g.
+// "4 → +8 → ×2" for the mult
+func scoringTrail(start int, steps []ScoringStep, add, times JokerEffect) string {
+	parts := []string{fmt.Sprintf("%d", start)}
+	for _, step := range steps {
+		switch step.Effect {
+		case add:
+			parts = append(parts, fmt.Sprintf("%+d", step.Amount))
+		case times:
+			parts = append(parts, fmt.Sprintf("×%d", step.Amount))
+		}
+	}
+	return strings.Join(parts, " → ")
+}
+
+// hasStepEffect reports whether any joker step has the effect
+func hasStepEffect(steps []ScoringStep, effect JokerEffect) bool {
+	for _, step := range steps {
+		if step.Effect == effect {
+			return true
+		}
+	}
+	return false
+}
@@ -46,3 +46,28 @@ func TestGetShopActionParsesReroll(t *testing.T) {
 		t.Fatalf("expected no params, got %v", params)
 	}
 }
+
+func TestGetPackActionParsesPick(t *testing.T) {
+	for _, input := range []string{"pick 2\n", "2\n"} {
+		handler := NewLoggerEventHandlerFromReader(strings.NewReader(input))
+		action, params, quit := handler.GetPackAction()
+		if quit || action != PlayerActionPickCard || len(params) != 1 || params[0] != "2" {
+			t.Fatalf("input %q: expected pick 2, got %s %v quit=%v", input, action, params, quit)
+		}
+	}
+
+	handler := NewLoggerEventHandlerFromReader(strings.NewReader("skip\n"))
+	if action, _, _ := handler.GetPackAction(); action != PlayerActionSkipPack {
+		t.Fatalf("expected skip, got %s", action)
+	}
+}
+
+// TestDeckViewWaitsForEnter verifies the console deck view consumes a line of
+// input before play continues.
+func TestDeckViewWaitsForEnter(t *testing.T) {
+	handler := NewLoggerEventHandlerFromReader(strings.NewReader("\nreroll\n"))
+	handler.HandleEvent(DeckViewedEvent{Remaining: []Card{{Rank: Ace, Suit: Spades}}})
+	if action, _, _ := handler.GetShopAction(); action != PlayerActionReroll {
+		t.Fatalf("expected the deck view to wait for Enter before the next command, got %s", action)
+	}
+}
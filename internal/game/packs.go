@@ -0,0 +1,354 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PackType identifies what kind of cards a booster pack holds
+type PackType string
+
+const (
+	// ArcanaPack holds Tarot cards
+	ArcanaPack PackType = "Arcana"
+	// CelestialPack holds Planet cards
+	CelestialPack PackType = "Celestial"
+	// SpectralPack holds Spectral cards
+	SpectralPack PackType = "Spectral"
+	// StandardPack holds playing cards to add to the deck
+	StandardPack PackType = "Standard"
+	// BuffoonPack holds jokers
+	BuffoonPack PackType = "Buffoon"
+)
+
+// GoldCardOdds gives each playing card in a Standard pack a 1 in
+// GoldCardOdds chance of being a Gold card
+const GoldCardOdds = 5
+
+// packTypes lists every pack type
+var packTypes = []string{string(ArcanaPack), string(CelestialPack), string(SpectralPack), string(StandardPack), string(BuffoonPack)}
+
+// PackSize is how many cards a booster pack offers
+type PackSize string
+
+const (
+	NormalPack PackSize = "normal"
+	JumboPack  PackSize = "jumbo"
+	MegaPack   PackSize = "mega"
+)
+
+// packSizes lists every pack size
+var packSizes = []string{string(NormalPack), string(JumboPack), string(MegaPack)}
+
+// BoosterPack is a pack the shop can sell: the player picks some of the
+// cards inside and the rest are discarded
+type BoosterPack struct {
+	Type PackType `yaml:"type"`
+	Size PackSize `yaml:"size"`
+	// Cards is how many cards the pack shows
+	Cards int `yaml:"cards"`
+	// Picks is how many of them the player keeps
+	Picks int `yaml:"picks"`
+	Price int `yaml:"price"`
+	// Weight is the relative odds of the pack filling a shop slot
+	Weight int `yaml:"weight"`
+}
+
+// Name returns the pack's display name, e.g. "Jumbo Arcana Pack"
+func (p BoosterPack) Name() string {
+	if p.Size == NormalPack {
+		return fmt.Sprintf("%s Pack", p.Type)
+	}
+	return fmt.Sprintf("%s%s %s Pack", strings.ToUpper(string(p.Size[:1])), p.Size[1:], p.Type)
+}
+
+// Description returns what the pack holds
+func (p BoosterPack) Description() string {
+	return fmt.Sprintf("Choose %d of %d %s", p.Picks, p.Cards, p.contents())
+}
+
+// contents describes the kind of card inside the pack
+func (p BoosterPack) contents() string {
+	switch p.Type {
+	case ArcanaPack:
+		return "Tarot cards"
+	case CelestialPack:
+		return "Planet cards"
+	case SpectralPack:
+		return "Spectral cards"
+	case StandardPack:
+		return "playing cards"
+	case BuffoonPack:
+		return "Jokers"
+	default:
+		return "cards"
+	}
+}
+
+// PacksConfig lists the booster packs the shop can sell
+type PacksConfig struct {
+	// Slots is the number of packs for sale in each shop
+	Slots int           `yaml:"slots"`
+	Packs []BoosterPack `yaml:"packs"`
+}
+
+var packsConfig PacksConfig
+var packsLoaded bool
+
+// LoadPackConfigs loads booster pack definitions from YAML file with fallback to defaults
+func LoadPackConfigs() error {
+	if err := loadPacksFromYAML(); err != nil {
+		fmt.Printf("Warning: Could not load packs.yaml, using defaults: %v\n", err)
+		setDefaultPacks()
+	}
+	return nil
+}
+
+// loadPacksFromYAML loads booster pack definitions from YAML file
+func loadPacksFromYAML() error {
+	file, err := os.Open(filepath.Join("internal", "game", "packs.yaml"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	config, err := parsePacksYAML(data)
+	if err != nil {
+		return err
+	}
+	packsConfig = config
+	packsLoaded = true
+	return nil
+}
+
+// parsePacksYAML decodes and validates booster pack definitions
+func parsePacksYAML(data []byte) (PacksConfig, error) {
+	var config PacksConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return PacksConfig{}, err
+	}
+	if config.Slots < 0 {
+		return PacksConfig{}, fmt.Errorf("slots can't be negative, got %d", config.Slots)
+	}
+
+	total := 0
+	for i, pack := range config.Packs {
+		if !containsString(packTypes, string(pack.Type)) {
+			return PacksConfig{}, fmt.Errorf("pack %d: unknown type %q (expected one of %s)", i+1, pack.Type, strings.Join(packTypes, ", "))
+		}
+		if !containsString(packSizes, string(pack.Size)) {
+			return PacksConfig{}, fmt.Errorf("%s: unknown size %q (expected one of %s)", pack.Type, pack.Size, strings.Join(packSizes, ", "))
+		}
+		if pack.Cards < 1 || pack.Picks < 1 || pack.Picks > pack.Cards {
+			return PacksConfig{}, fmt.Errorf("%s: picks must be between 1 and cards (%d), got %d", pack.Name(), pack.Cards, pack.Picks)
+		}
+		if pack.Price < 0 || pack.Weight < 0 {
+			return PacksConfig{}, fmt.Errorf("%s: price and weight can't be negative", pack.Name())
+		}
+		total += pack.Weight
+	}
+	if config.Slots > 0 && total == 0 {
+		return PacksConfig{}, fmt.Errorf("at least one pack must have a positive weight")
+	}
+	return config, nil
+}
+
+// setDefaultPacks sets the hardcoded default booster packs
+func setDefaultPacks() {
+	packsConfig = PacksConfig{
+		Slots: 2,
+		Packs: []BoosterPack{
+			{Type: ArcanaPack, Size: NormalPack, Cards: 3, Picks: 1, Price: 4, Weight: 80},
+			{Type: ArcanaPack, Size: JumboPack, Cards: 5, Picks: 1, Price: 6, Weight: 40},
+			{Type: ArcanaPack, Size: MegaPack, Cards: 5, Picks: 2, Price: 8, Weight: 10},
+			{Type: CelestialPack, Size: NormalPack, Cards: 3, Picks: 1, Price: 4, Weight: 80},
+			{Type: CelestialPack, Size: JumboPack, Cards: 5, Picks: 1, Price: 6, Weight: 40},
+			{Type: CelestialPack, Size: MegaPack, Cards: 5, Picks: 2, Price: 8, Weight: 10},
+			{Type: SpectralPack, Size: NormalPack, Cards: 2, Picks: 1, Price: 4, Weight: 12},
+			{Type: SpectralPack, Size: JumboPack, Cards: 4, Picks: 1, Price: 6, Weight: 6},
+			{Type: SpectralPack, Size: MegaPack, Cards: 4, Picks: 2, Price: 8, Weight: 1},
+			{Type: StandardPack, Size: NormalPack, Cards: 3, Picks: 1, Price: 4, Weight: 80},
+			{Type: StandardPack, Size: JumboPack, Cards: 5, Picks: 1, Price: 6, Weight: 40},
+			{Type: StandardPack, Size: MegaPack, Cards: 5, Picks: 2, Price: 8, Weight: 10},
+			{Type: BuffoonPack, Size: NormalPack, Cards: 2, Picks: 1, Price: 4, Weight: 24},
+			{Type: BuffoonPack, Size: JumboPack, Cards: 4, Picks: 1, Price: 6, Weight: 12},
+			{Type: BuffoonPack, Size: MegaPack, Cards: 4, Picks: 2, Price: 8, Weight: 3},
+		},
+	}
+	packsLoaded = true
+}
+
+// GetPacksConfig returns the loaded booster pack configuration
+func GetPacksConfig() PacksConfig {
+	if !packsLoaded {
+		setDefaultPacks()
+	}
+	return packsConfig
+}
+
+// GetPack returns the booster pack of the given type and size if it exists
+func GetPack(packType PackType, size PackSize) (BoosterPack, bool) {
+	for _, pack := range GetPacksConfig().Packs {
+		if pack.Type == packType && pack.Size == size {
+			return pack, true
+		}
+	}
+	return BoosterPack{}, false
+}
+
+// rollShopPacks fills the shop's pack slots with weighted random packs
+func (g *Game) rollShopPacks() []ShopItem {
+	config := GetPacksConfig()
+	weights := make([]int, len(config.Packs))
+	for i, pack := range config.Packs {
+		weights[i] = pack.Weight
+	}
+
+	var items []ShopItem
+	for len(items) < config.Slots {
+		pick := weightedChoice(g.shopRng(), weights)
+		if pick < 0 {
+			break
+		}
+		pack := config.Packs[pick]
+		pack.Price = g.shopPrice(pack.Price)
+		items = append(items, ShopItem{Pack: &pack})
+	}
+	return items
+}
+
+// PackCard is one of the cards inside an opened booster pack: a joker, a
+// consumable or a playing card. The zero value is a card already taken.
+type PackCard struct {
+	Joker      OwnedJoker
+	Consumable Consumable
+	Card       Card
+}
+
+// Empty reports whether the card has already been taken
+func (c PackCard) Empty() bool {
+	return c.Joker.Name == "" && c.Consumable.Name == "" && c.Card.Rank == 0
+}
+
+// Data converts the card for display
+func (c PackCard) Data() PackCardData {
+	switch {
+	case c.Empty():
+		return PackCardData{}
+	case c.Consumable.Name != "":
+		return PackCardData{
+			Name:        c.Consumable.Name,
+			Description: c.Consumable.Description(),
+			Type:        strings.ToLower(string(c.Consumable.Type)),
+		}
+	case c.Joker.Name != "":
+		return PackCardData{Name: c.Joker.Name, Description: c.Joker.Description, Type: "joker"}
+	case c.Card.Gold:
+		return PackCardData{
+			Name:        fmt.Sprintf("Gold %s", c.Card),
+			Description: fmt.Sprintf("Add to your deck; earns $%d when held in hand as a blind is defeated", GoldCardReward),
+			Type:        "card",
+		}
+	default:
+		return PackCardData{Name: c.Card.String(), Description: "Add to your deck", Type: "card"}
+	}
+}
+
+// packCardData converts pack cards for display, keeping taken cards so
+// indices stay stable
+func packCardData(cards []PackCard) []PackCardData {
+	var data []PackCardData
+	for _, card := range cards {
+		data = append(data, card.Data())
+	}
+	return data
+}
+
+// OpenPack is a booster pack the player is choosing cards from
+type OpenPack struct {
+	Pack      BoosterPack
+	Cards     []PackCard
+	PicksLeft int
+}
+
+// openPack rolls the cards inside a booster pack from the shop's random
+// source
+func (g *Game) openPack(pack BoosterPack) *OpenPack {
+	open := &OpenPack{Pack: pack, PicksLeft: pack.Picks}
+	r := g.shopRng()
+	var jokers []ShopItem
+	for len(open.Cards) < pack.Cards {
+		var card PackCard
+		switch pack.Type {
+		case ArcanaPack:
+			if r.Intn(100) < g.voucherBonus(VoucherSpectralInArcana) {
+				card.Consumable = spectralCards[r.Intn(len(spectralCards))]
+			} else {
+				card.Consumable = tarotCards[r.Intn(len(tarotCards))]
+			}
+		case CelestialPack:
+			card.Consumable = planetCards[r.Intn(len(planetCards))]
+		case SpectralPack:
+			card.Consumable = spectralCards[r.Intn(len(spectralCards))]
+		case StandardPack:
+			deck := NewDeck()
+			card.Card = deck[r.Intn(len(deck))]
+			card.Card.Gold = r.Intn(GoldCardOdds) == 0
+		case BuffoonPack:
+			joker, ok := g.rollJoker(jokers, GetShopConfig().RarityWeights)
+			if !ok {
+				return open
+			}
+			card.Joker = applyEdition(r, applyStakeStickers(r, g.stake, []OwnedJoker{newOwnedJoker(joker)})[0])
+			jokers = append(jokers, ShopItem{Joker: card.Joker})
+		}
+		open.Cards = append(open.Cards, card)
+	}
+	return open
+}
+
+// openedEvent describes the pack's remaining cards
+func (p *OpenPack) openedEvent() PackOpenedEvent {
+	return PackOpenedEvent{
+		Pack:      p.Pack.Name(),
+		Cards:     packCardData(p.Cards),
+		PicksLeft: p.PicksLeft,
+	}
+}
+
+// packCardBlocked explains why a pack card can't be taken right now, or
+// returns an empty string when it can
+func (g *Game) packCardBlocked(card PackCard) string {
+	switch {
+	case card.Consumable.Name != "":
+		return g.consumableBlocked(card.Consumable)
+	case card.Joker.Name != "" && !g.hasJokerRoom(card.Joker):
+		return g.noJokerRoomReason(card.Joker.Name)
+	}
+	return ""
+}
+
+// takePackCard gives the player a card picked from a pack. Tarot, Planet
+// and Spectral cards are used straight away.
+func (g *Game) takePackCard(card PackCard) []Event {
+	switch {
+	case card.Consumable.Name != "":
+		return g.useConsumable(card.Consumable)
+	case card.Joker.Name != "":
+		g.jokers = append(g.jokers, card.Joker)
+		return []Event{MessageEvent{Message: fmt.Sprintf("Added %s", card.Joker.Name), Type: "success"}}
+	default:
+		g.addCard(card.Card)
+		return []Event{MessageEvent{Message: fmt.Sprintf("Added %s to your deck", card.Card), Type: "success"}}
+	}
+}
@@ -0,0 +1,275 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// newPackTestGame returns a game with money and hand levels for opening packs.
+func newPackTestGame() *Game {
+	g := createTestGame(nil)
+	g.handLevels = map[string]int{}
+	for _, eval := range handEvaluators {
+		g.handLevels[eval.Name()] = 1
+	}
+	return g
+}
+
+// TestParsePacksYAML verifies pack definitions are validated on load.
+func TestParsePacksYAML(t *testing.T) {
+	config, err := parsePacksYAML([]byte(`
+slots: 1
+packs:
+  - {type: Celestial, size: jumbo, cards: 5, picks: 1, price: 6, weight: 1}
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if config.Slots != 1 || len(config.Packs) != 1 || config.Packs[0].Name() != "Jumbo Celestial Pack" {
+		t.Fatalf("unexpected config %+v", config)
+	}
+
+	invalid := []string{
+		"slots: -1",
+		"slots: 1\npacks:\n  - {type: Tarot, size: normal, cards: 3, picks: 1}",
+		"slots: 1\npacks:\n  - {type: Arcana, size: huge, cards: 3, picks: 1}",
+		"slots: 1\npacks:\n  - {type: Arcana, size: normal, cards: 3, picks: 4, weight: 1}",
+		"slots: 1\npacks:\n  - {type: Arcana, size: normal, cards: 3, picks: 1, weight: 0}",
+	}
+	for _, data := range invalid {
+		if _, err := parsePacksYAML([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+// TestBuyPackOpensPickPhase verifies buying a pack opens it and that the
+// pack closes once every pick is used.
+func TestBuyPackOpensPickPhase(t *testing.T) {
+	g := newPackTestGame()
+	pack, _ := GetPack(CelestialPack, MegaPack)
+	shop := &Shop{game: g, Packs: []ShopItem{{Pack: &pack}}}
+
+	events := shop.Buy(1)
+	if !shop.Opening() || g.money != 20-pack.Price {
+		t.Fatalf("expected the pack to open after paying $%d, money=%d", pack.Price, g.money)
+	}
+	opened, ok := events[len(events)-1].(PackOpenedEvent)
+	if !ok || len(opened.Cards) != pack.Cards || opened.PicksLeft != pack.Picks {
+		t.Fatalf("expected a PackOpenedEvent with %d cards, got %v", pack.Cards, events)
+	}
+	if _, ok := shop.Handle(PlayerActionBuy, []string{"1"})[0].(InvalidActionEvent); !ok {
+		t.Fatalf("expected shop actions to be rejected while a pack is open")
+	}
+
+	planet := shop.Pack.Cards[0].Consumable
+	shop.Pick(1)
+	if g.handLevels[planet.Hand] != 2 {
+		t.Fatalf("expected %s to level up %s, levels=%v", planet.Name, planet.Hand, g.handLevels)
+	}
+	if _, ok := shop.Pick(1)[0].(InvalidActionEvent); !ok {
+		t.Fatalf("expected picking a taken card to fail")
+	}
+
+	events = shop.Pick(2)
+	if shop.Opening() {
+		t.Fatalf("expected the pack to close after %d picks", pack.Picks)
+	}
+	if _, ok := events[len(events)-1].(PackClosedEvent); !ok {
+		t.Fatalf("expected a PackClosedEvent, got %v", events)
+	}
+	if len(g.consumables) != 0 {
+		t.Fatalf("expected pack consumables to be used straight away, got %v", g.consumables)
+	}
+}
+
+// TestStandardPackAddsCardToDeck verifies playing cards from a pack join the deck.
+func TestStandardPackAddsCardToDeck(t *testing.T) {
+	g := newPackTestGame()
+	g.deck = NewDeck()
+	pack, _ := GetPack(StandardPack, NormalPack)
+	shop := &Shop{game: g, Pack: g.openPack(pack)}
+
+	card := shop.Pack.Cards[2].Card
+	shop.Pick(3)
+	if len(g.deck) != 53 || g.deck[52] != card {
+		t.Fatalf("expected %s to be added to the deck", card)
+	}
+	if shop.Opening() {
+		t.Fatalf("expected a normal pack to close after one pick")
+	}
+}
+
+// TestPackFollowsSeed verifies a pack's cards come from the run seed.
+func TestPackFollowsSeed(t *testing.T) {
+	SetSeed(42)
+	pack, _ := GetPack(StandardPack, MegaPack)
+	open := func() []PackCard {
+		g := newPackTestGame()
+		g.currentAnte = 3
+		return g.openPack(pack).Cards
+	}
+	first, second := open(), open()
+	if len(first) == 0 {
+		t.Fatalf("expected a %s %s pack to have cards", MegaPack, StandardPack)
+	}
+	for i := range first {
+		if first[i].Card != second[i].Card {
+			t.Fatalf("expected the same pack for the same seed, got %v and %v", first, second)
+		}
+	}
+}
+
+// TestStandardPackGoldCards verifies Standard packs can hold Gold cards,
+// which say so when shown.
+func TestStandardPackGoldCards(t *testing.T) {
+	SetSeed(3)
+	g := newPackTestGame()
+	pack, _ := GetPack(StandardPack, MegaPack)
+	for i := 0; i < 20; i++ {
+		for _, card := range g.openPack(pack).Cards {
+			if card.Card.Gold {
+				if data := card.Data(); !strings.HasPrefix(data.Name, "Gold ") {
+					t.Fatalf("expected a Gold card to be shown as one, got %+v", data)
+				}
+				return
+			}
+		}
+	}
+	t.Fatalf("expected a Gold card in 20 %s %s packs", MegaPack, StandardPack)
+}
+
+// TestConsumableWithNoEffectIsKept verifies a consumable that can't be used
+// stays in its slot.
+func TestConsumableWithNoEffectIsKept(t *testing.T) {
+	g := newPackTestGame()
+	mystery := Consumable{Type: TarotCard, Name: "Mystery"}
+	mars, _ := GetConsumableByName("Mars")
+	g.consumables = []Consumable{mars, mystery}
+
+	g.handleUseConsumableAction([]string{"2"})
+	if len(g.consumables) != 2 || g.consumables[1] != mystery {
+		t.Fatalf("expected %s to stay in its slot, got %v", mystery.Name, g.consumables)
+	}
+}
+
+// TestWraithWithNoRareJokerIsKept verifies Wraith doesn't take the player's
+// money when every Rare joker is already owned.
+func TestWraithWithNoRareJokerIsKept(t *testing.T) {
+	setDefaultJokerConfigs()
+	withShopConfig(t, ShopConfig{Slots: 1, RarityWeights: map[string]int{RarityCommon: 1, RarityRare: 1}})
+	g := newPackTestGame()
+	for _, joker := range GetAvailableJokers() {
+		if joker.Rarity == RarityRare {
+			g.jokers = append(g.jokers, newOwnedJoker(joker))
+		}
+	}
+	g.money = 20
+	wraith, _ := GetConsumableByName("Wraith")
+	g.consumables = []Consumable{wraith}
+	owned := len(g.jokers)
+
+	g.handleUseConsumableAction([]string{"1"})
+	if g.money != 20 || len(g.jokers) != owned || len(g.consumables) != 1 {
+		t.Fatalf("expected Wraith to stay unused, money=%d jokers=%d consumables=%v", g.money, len(g.jokers), g.consumables)
+	}
+
+	judgement, _ := GetConsumableByName("Judgement")
+	withShopConfig(t, ShopConfig{Slots: 1, RarityWeights: map[string]int{RarityRare: 1}})
+	if _, ok := g.useConsumable(judgement)[0].(InvalidActionEvent); !ok || len(g.jokers) != owned {
+		t.Fatalf("expected Judgement to fail with no joker left to create, jokers=%d", len(g.jokers))
+	}
+}
+
+// TestRunShopSkipsPack verifies the shop loop asks for pack actions while a
+// pack is open and returns to the shop when it is skipped.
+func TestRunShopSkipsPack(t *testing.T) {
+	handler := &testEventHandler{
+		shopActions: []struct {
+			action PlayerAction
+			params []string
+		}{
+			{PlayerActionBuy, []string{"1"}},
+			{PlayerActionExitShop, nil},
+		},
+	}
+	g := newPackTestGame()
+	g.eventEmitter.SetEventHandler(handler)
+	pack, _ := GetPack(BuffoonPack, NormalPack)
+
+	g.runShop(&Shop{game: g, Packs: []ShopItem{{Pack: &pack}}})
+
+	var closed, shopClosed bool
+	for _, e := range handler.events {
+		switch e.(type) {
+		case PackClosedEvent:
+			closed = true
+		case ShopClosedEvent:
+			shopClosed = closed
+		}
+	}
+	if !closed || !shopClosed {
+		t.Fatalf("expected the pack to be skipped before leaving the shop")
+	}
+	if len(g.jokers) != 0 {
+		t.Fatalf("expected skipping to take no jokers, got %v", g.jokers)
+	}
+}
+
+// TestTarotAndSpectralEffects verifies the consumables packs can hold.
+func TestTarotAndSpectralEffects(t *testing.T) {
+	g := newPackTestGame()
+	g.money = 15
+	hermit, _ := GetConsumableByName("The Hermit")
+	g.useConsumable(hermit)
+	if g.money != 30 {
+		t.Fatalf("expected The Hermit to double $15, got $%d", g.money)
+	}
+	g.useConsumable(hermit)
+	if g.money != 30+HermitMax {
+		t.Fatalf("expected The Hermit to be capped at $%d, got $%d", HermitMax, g.money)
+	}
+
+	g.money = 0
+	g.jokers = []OwnedJoker{{Joker: Joker{Name: "A", Price: 6}, PurchasePrice: 6}, {Joker: Joker{Name: "B", Price: 5}, PurchasePrice: 5}}
+	temperance, _ := GetConsumableByName("Temperance")
+	g.useConsumable(temperance)
+	if g.money != 5 {
+		t.Fatalf("expected Temperance to pay the jokers' sell value $5, got $%d", g.money)
+	}
+
+	blackHole, _ := GetConsumableByName("Black Hole")
+	g.useConsumable(blackHole)
+	for hand, level := range g.handLevels {
+		if level != 2 {
+			t.Errorf("expected Black Hole to level up %s, got level %d", hand, level)
+		}
+	}
+
+	emperor, _ := GetConsumableByName("The Emperor")
+	g.useConsumable(emperor)
+	if len(g.consumables) != TarotCopies || g.consumables[0].Type != TarotCard {
+		t.Fatalf("expected The Emperor to create %d Tarot cards, got %v", TarotCopies, g.consumables)
+	}
+
+	wraith, _ := GetConsumableByName("Wraith")
+	g.useConsumable(wraith)
+	if g.money != 0 || len(g.jokers) != 3 || g.jokers[2].Rarity != RarityRare {
+		t.Fatalf("expected Wraith to create a Rare joker and empty wallet, money=%d jokers=%v", g.money, g.jokers)
+	}
+}
+
+// TestBuffoonTagAddsFreePack verifies the Buffoon Tag stocks a free Mega Buffoon Pack.
+func TestBuffoonTagAddsFreePack(t *testing.T) {
+	g := newPackTestGame()
+	g.tags = []Tag{{Type: BuffoonTag}}
+	shop := &Shop{game: g}
+	g.applyShopTags(shop)
+
+	if len(shop.Packs) != 1 || shop.Packs[0].Name() != "Mega Buffoon Pack" || shop.Packs[0].Price() != 0 {
+		t.Fatalf("expected a free Mega Buffoon Pack, got %v", shop.Packs)
+	}
+	if len(g.tags) != 0 {
+		t.Fatalf("expected the Buffoon Tag to be used, got %v", g.tags)
+	}
+}
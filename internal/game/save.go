@@ -17,6 +17,59 @@ type saveFile struct {
 	CurrentMoney  int            `json:"current_money"`
 	CurrentJokers []string       `json:"current_jokers"`
 	HandLevels    map[string]int `json:"hand_levels"`
+	Deck          string         `json:"deck,omitempty"`
+	Stake         string         `json:"stake,omitempty"`
+	Endless       bool           `json:"endless,omitempty"`
+	// JokerStates lines up with CurrentJokers. Saves before version 14 call
+	// it joker_stickers and don't record purchase prices.
+	JokerStates   []savedJoker `json:"joker_states,omitempty"`
+	JokerStickers []savedJoker `json:"joker_stickers,omitempty"`
+	Tags          []string     `json:"tags,omitempty"`
+	// Phase is empty for saves made while playing a blind
+	Phase       string   `json:"phase,omitempty"`
+	Consumables []string `json:"consumables,omitempty"`
+	Vouchers    []string `json:"vouchers,omitempty"`
+	// VoucherAnte is the ante whose voucher has been bought
+	VoucherAnte int `json:"voucher_ante,omitempty"`
+	// AddedCards are put back into the starting deck when loading, before
+	// DestroyedCards are taken out of it
+	AddedCards     []savedCard `json:"added_cards,omitempty"`
+	DestroyedCards []savedCard `json:"destroyed_cards,omitempty"`
+}
+
+type savedCard struct {
+	Rank string `json:"rank"`
+	Suit string `json:"suit"`
+	Gold bool   `json:"gold,omitempty"`
+	Seal Seal   `json:"seal,omitempty"`
+}
+
+// saveCard converts a card for saving
+func saveCard(c Card) savedCard {
+	return savedCard{Rank: c.Rank.String(), Suit: c.Suit.Name(), Gold: c.Gold, Seal: c.Seal}
+}
+
+// card converts a saved card back into a Card
+func (sc savedCard) card() (Card, error) {
+	rank, err := ParseRank(sc.Rank)
+	if err != nil {
+		return Card{}, err
+	}
+	suit, err := ParseSuit(sc.Suit)
+	if err != nil {
+		return Card{}, err
+	}
+	return Card{Rank: rank, Suit: suit, Gold: sc.Gold, Seal: sc.Seal}, nil
+}
+
+type savedJoker struct {
+	PurchasePrice int          `json:"purchase_price"`
+	Sticker       JokerSticker `json:"sticker,omitempty"`
+	RoundsLeft    int          `json:"rounds_left,omitempty"`
+	Debuffed      bool         `json:"debuffed,omitempty"`
+	Edition       JokerEdition `json:"edition,omitempty"`
+	SellBonus     int          `json:"sell_bonus,omitempty"`
+	Counter       int          `json:"counter,omitempty"`
 }
 
 func parseBlindType(name string) (BlindType, error) {
@@ -32,6 +85,17 @@ func parseBlindType(name string) (BlindType, error) {
 	}
 }
 
+func parseGamePhase(name string) (GamePhase, error) {
+	switch name {
+	case "", PhasePlaying.String():
+		return PhasePlaying, nil
+	case PhaseBlindSelect.String():
+		return PhaseBlindSelect, nil
+	default:
+		return PhasePlaying, fmt.Errorf("unknown game phase %q", name)
+	}
+}
+
 // LoadGameFromFile creates a Game using state from a JSON save file
 func LoadGameFromFile(path string, handler EventHandler) (*Game, error) {
 	data, err := ioutil.ReadFile(path)
@@ -44,7 +108,7 @@ func LoadGameFromFile(path string, handler EventHandler) (*Game, error) {
 		return nil, err
 	}
 
-	if save.SaveVersion != 1 && save.SaveVersion != 2 {
+	if save.SaveVersion < 1 || save.SaveVersion > 14 {
 		return nil, fmt.Errorf("unsupported save version: %d", save.SaveVersion)
 	}
 
@@ -52,7 +116,15 @@ func LoadGameFromFile(path string, handler EventHandler) (*Game, error) {
 		SetSeed(save.Seed)
 	}
 
-	g := NewGame(handler)
+	// Saves before version 3 have no deck or stake and use the defaults
+	stake, err := ParseStake(save.Stake)
+	if err != nil {
+		return nil, err
+	}
+	g, err := NewGameWithConfig(handler, RunConfig{Deck: save.Deck, Stake: stake})
+	if err != nil {
+		return nil, err
+	}
 	g.currentAnte = save.CurrentAnte
 	bt, err := parseBlindType(save.CurrentBlind)
 	if err != nil {
@@ -60,6 +132,13 @@ func LoadGameFromFile(path string, handler EventHandler) (*Game, error) {
 	}
 	g.currentBlind = bt
 	g.money = save.CurrentMoney
+	g.endless = save.Endless
+	// Saves before version 6 were always made while playing a blind
+	phase, err := parseGamePhase(save.Phase)
+	if err != nil {
+		return nil, err
+	}
+	g.phase = phase
 
 	// Load hand levels
 	if save.SaveVersion >= 2 && save.HandLevels != nil {
@@ -79,33 +158,145 @@ func LoadGameFromFile(path string, handler EventHandler) (*Game, error) {
 		}
 	}
 
-	g.jokers = []Joker{}
-	for _, name := range save.CurrentJokers {
-		if joker, ok := GetJokerByName(name); ok {
-			g.jokers = append(g.jokers, joker)
-		} else {
+	states := save.JokerStates
+	if save.SaveVersion < 14 {
+		states = save.JokerStickers
+	}
+	g.jokers = []OwnedJoker{}
+	for i, name := range save.CurrentJokers {
+		base, ok := GetJokerByName(name)
+		if !ok {
 			return nil, fmt.Errorf("unknown joker: %s", name)
 		}
+		joker := newOwnedJoker(base)
+		if i < len(states) {
+			joker.Sticker = states[i].Sticker
+			joker.RoundsLeft = states[i].RoundsLeft
+			joker.Debuffed = states[i].Debuffed
+			joker.Edition = states[i].Edition
+			joker.SellBonus = states[i].SellBonus
+			joker.Counter = states[i].Counter
+			if save.SaveVersion >= 14 {
+				joker.PurchasePrice = states[i].PurchasePrice
+			} else if joker.Negative() {
+				joker.PurchasePrice += NegativePrice
+			}
+		}
+		g.jokers = append(g.jokers, joker)
+	}
+
+	for _, name := range save.Consumables {
+		c, ok := GetConsumableByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown consumable: %s", name)
+		}
+		g.consumables = append(g.consumables, c)
+	}
+
+	for _, name := range save.Vouchers {
+		v, ok := GetVoucherByName(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown voucher: %s", name)
+		}
+		g.vouchers = append(g.vouchers, v)
+	}
+	g.voucherAnte = save.VoucherAnte
+
+	for _, name := range save.Tags {
+		tag, err := ParseTag(name)
+		if err != nil {
+			return nil, err
+		}
+		g.tags = append(g.tags, tag)
+	}
+
+	for _, sc := range save.AddedCards {
+		card, err := sc.card()
+		if err != nil {
+			return nil, fmt.Errorf("added card: %v", err)
+		}
+		g.addCard(card)
+	}
+	if err := g.restoreDestroyedCards(save.DestroyedCards); err != nil {
+		return nil, err
 	}
 
-	g.currentTarget = GetAnteRequirement(g.currentAnte, g.currentBlind)
+	if g.phase == PhaseBlindSelect {
+		// The blind is set up once the player chooses it
+		return g, nil
+	}
+	g.currentTarget = g.blindTarget(g.currentAnte, g.currentBlind)
+	if g.currentBlind == BossBlind {
+		// Money effects already happened before saving; only restore the target
+		g.currentBoss = GetBossForAnte(g.currentAnte)
+		g.applyBossTargetEffects()
+	}
+	// The hand isn't saved, so a blind in progress resumes with a fresh one
+	g.dealHand()
+	if g.currentBlind == BossBlind {
+		g.applyBossJokerEffects()
+	}
 	return g, nil
 }
 
+// restoreDestroyedCards takes saved destroyed cards out of the freshly built
+// deck
+func (g *Game) restoreDestroyedCards(cards []savedCard) error {
+	for _, sc := range cards {
+		card, err := sc.card()
+		if err != nil {
+			return fmt.Errorf("destroyed card: %v", err)
+		}
+		g.destroyCard(card)
+	}
+	return nil
+}
+
 // Save writes the current game state to a timestamped JSON file
 func (g *Game) Save() (string, error) {
 	save := saveFile{
-		SaveVersion:   2,
+		SaveVersion:   14,
 		Seed:          GetSeed(),
 		CurrentAnte:   g.currentAnte,
 		CurrentBlind:  g.currentBlind.String(),
 		CurrentMoney:  g.money,
 		CurrentJokers: make([]string, len(g.jokers)),
 		HandLevels:    g.handLevels,
+		Deck:          g.startingDeck.Name,
+		Stake:         g.stake.String(),
+		Endless:       g.endless,
+		VoucherAnte:   g.voucherAnte,
+	}
+	if g.phase != PhasePlaying {
+		save.Phase = g.phase.String()
 	}
 
 	for i, joker := range g.jokers {
 		save.CurrentJokers[i] = joker.Name
+		save.JokerStates = append(save.JokerStates, savedJoker{
+			PurchasePrice: joker.PurchasePrice,
+			Sticker:       joker.Sticker,
+			RoundsLeft:    joker.RoundsLeft,
+			Debuffed:      joker.Debuffed,
+			Edition:       joker.Edition,
+			SellBonus:     joker.SellBonus,
+			Counter:       joker.Counter,
+		})
+	}
+	for _, tag := range g.tags {
+		save.Tags = append(save.Tags, tag.Name())
+	}
+	for _, c := range g.consumables {
+		save.Consumables = append(save.Consumables, c.Name)
+	}
+	for _, v := range g.vouchers {
+		save.Vouchers = append(save.Vouchers, v.Name)
+	}
+	for _, c := range g.addedCards {
+		save.AddedCards = append(save.AddedCards, saveCard(c))
+	}
+	for _, c := range g.destroyedCards {
+		save.DestroyedCards = append(save.DestroyedCards, saveCard(c))
 	}
 
 	data, err := json.MarshalIndent(save, "", "  ")
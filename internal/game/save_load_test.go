@@ -16,6 +16,7 @@ func TestLoadGameFromFile(t *testing.T) {
 		CurrentMoney:  10,
 		CurrentJokers: []string{"The Golden Joker"},
 		HandLevels:    map[string]int{"Pair": 2},
+		Deck:          "Red Deck",
 	}
 
 	tmp, err := os.CreateTemp("", "save*.json")
@@ -49,20 +50,40 @@ func TestLoadGameFromFile(t *testing.T) {
 	if g.handLevels["Pair"] != 2 {
 		t.Errorf("hand level Pair = %d, want 2", g.handLevels["Pair"])
 	}
+	if g.startingDeck.Name != "Red Deck" {
+		t.Errorf("deck = %s, want Red Deck", g.startingDeck.Name)
+	}
 
+	// A save without a phase resumes the blind, dealing from the seeded deck
 	SetSeed(123)
 	expected := NewGame(NewLoggerEventHandler())
+	expected.dealHand()
 	for i, card := range expected.deck {
 		if g.deck[i] != card {
 			t.Fatalf("deck differs at %d", i)
 		}
 	}
+	if g.phase != PhasePlaying || len(g.playerCards) != g.handSize() || g.deckIndex != g.handSize() {
+		t.Fatalf("loaded %s with %d cards in hand (deck index %d), want playing with %d", g.phase, len(g.playerCards), g.deckIndex, g.handSize())
+	}
 }
 
 func TestSaveGameToFile(t *testing.T) {
 	SetSeed(456)
 	g := NewGame(NewLoggerEventHandler())
 	g.LevelUpHand("Pair")
+	g.tags = []Tag{{Type: InvestmentTag}}
+	mars, _ := GetConsumableByName("Mars")
+	g.consumables = []Consumable{mars}
+	grabber, _ := GetVoucherByName("Grabber")
+	g.redeemVoucher(grabber)
+	negative := newOwnedJoker(GetGoldenJoker())
+	negative.Edition = NegativeEdition
+	negative.PurchasePrice = 3
+	negative.SellBonus = 6
+	negative.Counter = 4
+	g.jokers = append(g.jokers, negative)
+	g.destroyCard(g.deck[0])
 
 	filename, err := g.Save()
 	if err != nil {
@@ -95,7 +116,139 @@ func TestSaveGameToFile(t *testing.T) {
 	if len(save.CurrentJokers) != len(g.jokers) {
 		t.Errorf("jokers = %v, want %v", save.CurrentJokers, g.jokers)
 	}
+	if save.Deck != DefaultDeckName {
+		t.Errorf("deck = %s, want %s", save.Deck, DefaultDeckName)
+	}
 	if save.HandLevels["Pair"] != g.handLevels["Pair"] {
 		t.Errorf("hand level saved = %d, want %d", save.HandLevels["Pair"], g.handLevels["Pair"])
 	}
+	if len(save.Tags) != 1 || save.Tags[0] != string(InvestmentTag) {
+		t.Errorf("tags = %v, want [%s]", save.Tags, InvestmentTag)
+	}
+	if save.Phase != PhaseBlindSelect.String() {
+		t.Errorf("phase = %q, want %q", save.Phase, PhaseBlindSelect)
+	}
+
+	loaded, err := LoadGameFromFile(filename, NewLoggerEventHandler())
+	if err != nil {
+		t.Fatalf("LoadGameFromFile returned error: %v", err)
+	}
+	if loaded.phase != PhaseBlindSelect {
+		t.Errorf("loaded phase = %s, want %s", loaded.phase, PhaseBlindSelect)
+	}
+	if len(loaded.vouchers) != 1 || loaded.vouchers[0] != grabber || loaded.voucherAnte != g.currentAnte {
+		t.Errorf("loaded vouchers = %v (ante %d), want [%s] (ante %d)", loaded.vouchers, loaded.voucherAnte, grabber.Name, g.currentAnte)
+	}
+	if loaded.maxHands() != MaxHands+1 {
+		t.Errorf("loaded max hands = %d, want %d", loaded.maxHands(), MaxHands+1)
+	}
+	if len(loaded.consumables) != 1 || loaded.consumables[0] != mars {
+		t.Errorf("loaded consumables = %v, want [%s]", loaded.consumables, mars.Name)
+	}
+	if last := loaded.jokers[len(loaded.jokers)-1]; !last.Negative() || last.PurchasePrice != 3 || last.SellValue() != negative.SellValue() || last.SellBonus != 6 || last.Counter != 4 {
+		t.Errorf("loaded joker = %+v, want a Negative %s", last, negative.Name)
+	}
+	destroyed := g.destroyedCards[0]
+	if len(loaded.deck) != len(g.deck) || len(loaded.destroyedCards) != 1 {
+		t.Fatalf("loaded deck has %d cards (%d destroyed), want %d (1 destroyed)", len(loaded.deck), len(loaded.destroyedCards), len(g.deck))
+	}
+	for _, c := range append(loaded.deck, loaded.playerCards...) {
+		if c.Rank == destroyed.Rank && c.Suit == destroyed.Suit {
+			t.Fatalf("expected the destroyed %s to stay out of the loaded deck", destroyed)
+		}
+	}
+}
+
+// TestLoadBlindInProgress verifies a save taken mid-blind resumes with a
+// full hand dealt from the deck left after destroyed cards are removed.
+func TestLoadBlindInProgress(t *testing.T) {
+	SetSeed(321)
+	g := NewGame(NewLoggerEventHandler())
+	g.startBlind()
+	destroyed := g.playerCards[0]
+	g.destroyCard(destroyed)
+
+	filename, err := g.Save()
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(filename))
+
+	loaded, err := LoadGameFromFile(filename, NewLoggerEventHandler())
+	if err != nil {
+		t.Fatalf("LoadGameFromFile returned error: %v", err)
+	}
+	if loaded.phase != PhasePlaying || len(loaded.playerCards) != loaded.handSize() || len(loaded.displayToOriginal) != len(loaded.playerCards) {
+		t.Fatalf("loaded %s with %d cards in hand (%d mapped), want playing with %d", loaded.phase, len(loaded.playerCards), len(loaded.displayToOriginal), loaded.handSize())
+	}
+	if len(loaded.deck) != len(g.deck) {
+		t.Fatalf("loaded deck has %d cards, want %d", len(loaded.deck), len(g.deck))
+	}
+	for _, c := range loaded.playerCards {
+		if c.Rank == destroyed.Rank && c.Suit == destroyed.Suit {
+			t.Fatalf("expected the destroyed %s to stay out of the loaded hand", destroyed)
+		}
+	}
+}
+
+// TestLoadJokerStickers verifies saves from before version 14, which have no
+// purchase prices, price their jokers from the config.
+func TestLoadJokerStickers(t *testing.T) {
+	save := saveFile{
+		SaveVersion:   13,
+		CurrentAnte:   1,
+		CurrentBlind:  SmallBlind.String(),
+		CurrentJokers: []string{"The Golden Joker"},
+		JokerStickers: []savedJoker{{Edition: NegativeEdition, SellBonus: 2}},
+	}
+	data, err := json.Marshal(save)
+	if err != nil {
+		t.Fatalf("encoding save: %v", err)
+	}
+	filename := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		t.Fatalf("writing save: %v", err)
+	}
+
+	g, err := LoadGameFromFile(filename, NewLoggerEventHandler())
+	if err != nil {
+		t.Fatalf("LoadGameFromFile returned error: %v", err)
+	}
+	joker := g.jokers[0]
+	if want := GetGoldenJoker().Price + NegativePrice; joker.PurchasePrice != want || joker.SellBonus != 2 {
+		t.Errorf("loaded joker = %+v, want a purchase price of $%d and a sell bonus of $2", joker, want)
+	}
+}
+
+// TestSaveLoadAddedCards verifies cards added to the deck from a pack are
+// still in it after loading.
+func TestSaveLoadAddedCards(t *testing.T) {
+	SetSeed(789)
+	g := NewGame(NewLoggerEventHandler())
+	added := Card{Rank: King, Suit: Hearts, Gold: true}
+	g.takePackCard(PackCard{Card: added})
+	size := len(g.deck)
+
+	filename, err := g.Save()
+	if err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	defer os.RemoveAll(filepath.Dir(filename))
+
+	loaded, err := LoadGameFromFile(filename, NewLoggerEventHandler())
+	if err != nil {
+		t.Fatalf("LoadGameFromFile returned error: %v", err)
+	}
+	if len(loaded.deck) != size {
+		t.Fatalf("loaded deck has %d cards, want %d", len(loaded.deck), size)
+	}
+	found := false
+	for _, c := range loaded.deck {
+		if c == added {
+			found = true
+		}
+	}
+	if !found || len(loaded.addedCards) != 1 {
+		t.Errorf("expected the Gold %s added from a pack to be in the loaded deck", added)
+	}
 }
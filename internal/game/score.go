@@ -0,0 +1,76 @@
+package game
+
+import (
+	"fmt"
+	"math"
+)
+
+// Score is a point total that saturates at MaxScore instead of overflowing.
+// Stacked multiplier jokers and endless mode targets grow fast enough to
+// overflow a plain int.
+type Score int64
+
+// MaxScore is the largest representable score; arithmetic clamps here
+const MaxScore Score = math.MaxInt64
+
+// scientificThreshold is the smallest score shown in scientific notation
+const scientificThreshold Score = 100_000_000_000
+
+// ScoreFromFloat converts a float to a Score, clamping instead of overflowing
+func ScoreFromFloat(f float64) Score {
+	if math.IsNaN(f) || f >= float64(MaxScore) {
+		return MaxScore
+	}
+	if f <= -float64(MaxScore) {
+		return -MaxScore
+	}
+	return Score(f)
+}
+
+// Add returns s + o, clamped to ±MaxScore
+func (s Score) Add(o Score) Score {
+	if o > 0 && s > MaxScore-o {
+		return MaxScore
+	}
+	if o < 0 && s < -MaxScore-o {
+		return -MaxScore
+	}
+	return s + o
+}
+
+// Mul returns s × o, clamped to ±MaxScore
+func (s Score) Mul(o Score) Score {
+	if s == 0 || o == 0 {
+		return 0
+	}
+	result := s * o
+	if result/o != s || result < -MaxScore {
+		if (s > 0) == (o > 0) {
+			return MaxScore
+		}
+		return -MaxScore
+	}
+	return result
+}
+
+// Saturated reports whether the score has hit the representable limit
+func (s Score) Saturated() bool {
+	return s >= MaxScore || s <= -MaxScore
+}
+
+// String renders the score for display, switching to scientific notation
+// (e.g. 1.23e15) once it gets too long to read
+func (s Score) String() string {
+	if s < scientificThreshold && s > -scientificThreshold {
+		return fmt.Sprintf("%d", int64(s))
+	}
+	f := float64(s)
+	exponent := int(math.Floor(math.Log10(math.Abs(f))))
+	mantissa := f / math.Pow(10, float64(exponent))
+	// Rounding can push the mantissa to 10.00
+	if math.Abs(mantissa) >= 9.995 {
+		mantissa /= 10
+		exponent++
+	}
+	return fmt.Sprintf("%.2fe%d", mantissa, exponent)
+}
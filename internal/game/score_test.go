@@ -0,0 +1,100 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+// TestScoreString verifies large scores switch to scientific notation.
+func TestScoreString(t *testing.T) {
+	tests := []struct {
+		score    Score
+		expected string
+	}{
+		{0, "0"},
+		{1650, "1650"},
+		{99_999_999_999, "99999999999"},
+		{100_000_000_000, "1.00e11"},
+		{1_230_000_000_000_000, "1.23e15"},
+		{999_900_000_000, "1.00e12"},
+		{-1_230_000_000_000_000, "-1.23e15"},
+		{MaxScore, "9.22e18"},
+	}
+	for _, tt := range tests {
+		if got := tt.score.String(); got != tt.expected {
+			t.Errorf("Score(%d).String() = %q, want %q", int64(tt.score), got, tt.expected)
+		}
+	}
+}
+
+// TestScoreSaturates verifies arithmetic clamps at the overflow boundary.
+func TestScoreSaturates(t *testing.T) {
+	if got := (MaxScore - 1).Add(1); got != MaxScore {
+		t.Errorf("MaxScore-1 + 1 = %d, want MaxScore", got)
+	}
+	if got := MaxScore.Add(1); got != MaxScore {
+		t.Errorf("MaxScore + 1 = %d, want MaxScore", got)
+	}
+	if got := (-MaxScore).Add(-1); got != -MaxScore {
+		t.Errorf("-MaxScore - 1 = %d, want -MaxScore", got)
+	}
+	if got := Score(1 << 31).Mul(1 << 31); got != 1<<62 {
+		t.Errorf("2^31 × 2^31 = %d, want 2^62", got)
+	}
+	if got := Score(1 << 32).Mul(1 << 32); got != MaxScore {
+		t.Errorf("2^32 × 2^32 = %d, want MaxScore", got)
+	}
+	if got := Score(-(1 << 32)).Mul(1 << 32); got != -MaxScore {
+		t.Errorf("-2^32 × 2^32 = %d, want -MaxScore", got)
+	}
+	if got := Score(-(1 << 62)).Mul(2); got != -MaxScore {
+		t.Errorf("-2^62 × 2 = %d, want -MaxScore", got)
+	}
+	if got := MaxScore.Mul(0); got != 0 {
+		t.Errorf("MaxScore × 0 = %d, want 0", got)
+	}
+	if !MaxScore.Saturated() || Score(1650).Saturated() {
+		t.Errorf("Saturated() reported the wrong result")
+	}
+	if got := ScoreFromFloat(1e300); got != MaxScore {
+		t.Errorf("ScoreFromFloat(1e300) = %d, want MaxScore", got)
+	}
+	if got := ScoreFromFloat(math.NaN()); got != MaxScore {
+		t.Errorf("ScoreFromFloat(NaN) = %d, want MaxScore", got)
+	}
+	if got := ScoreFromFloat(1234.9); got != 1234 {
+		t.Errorf("ScoreFromFloat(1234.9) = %d, want 1234", got)
+	}
+}
+
+// TestStackedMultiplyMultSaturates verifies stacked multiplier jokers can't
+// overflow a played hand's score.
+func TestStackedMultiplyMultSaturates(t *testing.T) {
+	cfg := JokerConfig{Name: "Ace Multiplier", Effects: []JokerEffectConfig{{Effect: MultiplyMult, EffectMagnitude: 1000, CardMatchingRule: CardIsAce}}}
+	joker := newOwnedJoker(createJokerFromConfig(cfg))
+	jokers := []OwnedJoker{joker, joker, joker, joker, joker}
+
+	hand := []Card{{Rank: Ace, Suit: Hearts}, {Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Clubs}, {Rank: Ace, Suit: Diamonds}, {Rank: King, Suit: Hearts}}
+	_, _, factor := CalculateJokerHandBonus(jokers, "Four of a Kind", hand)
+	if factor != MaxScore {
+		t.Fatalf("expected factor to saturate, got %d", factor)
+	}
+
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:          NewDeck(),
+		deckIndex:     7,
+		playerCards:   append(hand, Card{Rank: Two, Suit: Clubs}, Card{Rank: Three, Suit: Clubs}),
+		jokers:        jokers,
+		totalScore:    MaxScore - 10,
+		currentTarget: MaxScore,
+		eventEmitter:  NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handlePlayAction([]string{"1", "2", "3", "4", "5"})
+
+	if g.totalScore != MaxScore {
+		t.Fatalf("expected total score to saturate, got %d", g.totalScore)
+	}
+}
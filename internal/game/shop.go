@@ -0,0 +1,730 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Card types the shop can offer, as named in shop.yaml
+const (
+	ShopCardJoker  = "joker"
+	ShopCardPlanet = "planet"
+)
+
+// shopCardTypes lists the card types the shop can offer
+var shopCardTypes = []string{ShopCardJoker, ShopCardPlanet}
+
+// ShopConfig controls what the shop offers
+type ShopConfig struct {
+	// Slots is the number of cards for sale in each shop
+	Slots int `yaml:"slots"`
+	// AllowDuplicates lets jokers the player already owns appear
+	AllowDuplicates bool `yaml:"allow_duplicates"`
+	// CardWeights are the relative odds of each card type per slot
+	CardWeights map[string]int `yaml:"card_weights"`
+	// RarityWeights are the relative odds of each joker rarity
+	RarityWeights map[string]int `yaml:"rarity_weights"`
+	// NegativeOdds gives rolled jokers a 1 in NegativeOdds chance of being
+	// Negative; 0 turns Negative jokers off
+	NegativeOdds int `yaml:"negative_odds"`
+}
+
+var shopConfig ShopConfig
+
+// LoadShopConfig loads the shop configuration from YAML file with fallback to defaults
+func LoadShopConfig() error {
+	if err := loadShopFromYAML(); err != nil {
+		fmt.Printf("Warning: Could not load shop.yaml, using defaults: %v\n", err)
+		setDefaultShopConfig()
+	}
+	return nil
+}
+
+// loadShopFromYAML loads the shop configuration from YAML file
+func loadShopFromYAML() error {
+	file, err := os.Open(filepath.Join("internal", "game", "shop.yaml"))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	config, err := parseShopYAML(data)
+	if err != nil {
+		return err
+	}
+	shopConfig = config
+	return nil
+}
+
+// parseShopYAML decodes and validates the shop configuration
+func parseShopYAML(data []byte) (ShopConfig, error) {
+	var config ShopConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return ShopConfig{}, err
+	}
+	if config.Slots < 1 {
+		return ShopConfig{}, fmt.Errorf("slots must be at least 1, got %d", config.Slots)
+	}
+	if config.NegativeOdds < 0 {
+		return ShopConfig{}, fmt.Errorf("negative_odds can't be negative, got %d", config.NegativeOdds)
+	}
+	if err := validateWeights("card_weights", config.CardWeights, shopCardTypes); err != nil {
+		return ShopConfig{}, err
+	}
+	if err := validateWeights("rarity_weights", config.RarityWeights, Rarities); err != nil {
+		return ShopConfig{}, err
+	}
+	return config, nil
+}
+
+// validateWeights checks that every weight names a known key, none are
+// negative and at least one is positive
+func validateWeights(field string, weights map[string]int, known []string) error {
+	total := 0
+	for key, weight := range weights {
+		if !containsString(known, key) {
+			return fmt.Errorf("%s: unknown key %q (expected one of %s)", field, key, strings.Join(known, ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("%s: weight for %s can't be negative", field, key)
+		}
+		total += weight
+	}
+	if total == 0 {
+		return fmt.Errorf("%s: at least one weight must be positive", field)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// setDefaultShopConfig sets the hardcoded default shop configuration
+func setDefaultShopConfig() {
+	shopConfig = ShopConfig{
+		Slots:           2,
+		AllowDuplicates: false,
+		CardWeights: map[string]int{
+			ShopCardJoker:  20,
+			ShopCardPlanet: 4,
+		},
+		RarityWeights: map[string]int{
+			RarityCommon:    70,
+			RarityUncommon:  25,
+			RarityRare:      5,
+			RarityLegendary: 0,
+		},
+		NegativeOdds: 100,
+	}
+}
+
+// GetShopConfig returns the loaded shop configuration
+func GetShopConfig() ShopConfig {
+	if shopConfig.Slots == 0 {
+		setDefaultShopConfig()
+	}
+	return shopConfig
+}
+
+// ShopItem is something for sale in the shop: a joker, a consumable, a
+// booster pack or a voucher. The zero value is an empty slot.
+type ShopItem struct {
+	Joker      OwnedJoker
+	Consumable Consumable
+	Pack       *BoosterPack
+	Voucher    *Voucher
+	// VoucherPrice is what the voucher costs
+	VoucherPrice int
+}
+
+// Empty reports whether the slot has nothing for sale
+func (i ShopItem) Empty() bool {
+	return i.Joker.Name == "" && i.Consumable.Name == "" && i.Pack == nil && i.Voucher == nil
+}
+
+// IsConsumable reports whether the item is a consumable
+func (i ShopItem) IsConsumable() bool {
+	return i.Consumable.Name != ""
+}
+
+// IsJoker reports whether the item is a joker
+func (i ShopItem) IsJoker() bool {
+	return i.Joker.Name != ""
+}
+
+// IsPack reports whether the item is a booster pack
+func (i ShopItem) IsPack() bool {
+	return i.Pack != nil
+}
+
+// IsVoucher reports whether the item is a voucher
+func (i ShopItem) IsVoucher() bool {
+	return i.Voucher != nil
+}
+
+// Name returns the item's display name
+func (i ShopItem) Name() string {
+	switch {
+	case i.IsVoucher():
+		return i.Voucher.Name
+	case i.IsPack():
+		return i.Pack.Name()
+	case i.IsConsumable():
+		return i.Consumable.Name
+	default:
+		return i.Joker.Name
+	}
+}
+
+// Price returns what the item costs
+func (i ShopItem) Price() int {
+	switch {
+	case i.IsVoucher():
+		return i.VoucherPrice
+	case i.IsPack():
+		return i.Pack.Price
+	case i.IsConsumable():
+		return i.Consumable.Price
+	default:
+		return i.Joker.PurchasePrice
+	}
+}
+
+// Data converts the item for display given the player's money
+func (i ShopItem) Data(money int) ShopItemData {
+	switch {
+	case i.Empty():
+		return ShopItemData{}
+	case i.IsVoucher():
+		return ShopItemData{
+			Name:        i.Voucher.Name,
+			Description: i.Voucher.Description,
+			Cost:        i.VoucherPrice,
+			Type:        "voucher",
+			CanAfford:   money >= i.VoucherPrice,
+		}
+	case i.IsPack():
+		return ShopItemData{
+			Name:        i.Pack.Name(),
+			Description: i.Pack.Description(),
+			Cost:        i.Pack.Price,
+			Type:        "pack",
+			CanAfford:   money >= i.Pack.Price,
+		}
+	case !i.IsConsumable():
+		return NewShopItemData(i.Joker, money)
+	}
+	return ShopItemData{
+		Name:        i.Consumable.Name,
+		Description: i.Consumable.Description(),
+		Cost:        i.Consumable.Price,
+		Type:        strings.ToLower(string(i.Consumable.Type)),
+		CanAfford:   money >= i.Consumable.Price,
+	}
+}
+
+// shopItemData converts shop items for display, keeping empty slots so
+// indices stay stable
+func shopItemData(items []ShopItem, money int) []ShopItemData {
+	var data []ShopItemData
+	for _, item := range items {
+		data = append(data, item.Data(money))
+	}
+	return data
+}
+
+// shopSlots returns the number of cards for sale in each shop
+func (g *Game) shopSlots() int {
+	return GetShopConfig().Slots + g.voucherBonus(VoucherShopSlots)
+}
+
+// baseRerollCost returns what the first reroll in a shop costs
+func (g *Game) baseRerollCost() int {
+	cost := BaseRerollCost - g.voucherBonus(VoucherRerollDiscount)
+	if cost < 0 {
+		return 0
+	}
+	return cost
+}
+
+// rollShopItems fills the shop's slots with weighted random cards
+func (g *Game) rollShopItems() []ShopItem {
+	items := make([]ShopItem, 0, g.shopSlots())
+	for len(items) < g.shopSlots() {
+		item, ok := g.rollShopItem(items)
+		if !ok {
+			break
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// rollShopItem picks one card type by weight and then a card of that type.
+// It reports false when nothing can be offered.
+func (g *Game) rollShopItem(rolled []ShopItem) (ShopItem, bool) {
+	config := GetShopConfig()
+	jokers := g.shopJokerCandidates(rolled)
+
+	weights := make([]int, len(shopCardTypes))
+	for i, cardType := range shopCardTypes {
+		if cardType == ShopCardJoker && len(jokers) == 0 {
+			continue
+		}
+		weights[i] = config.CardWeights[cardType]
+	}
+
+	switch pick := weightedChoice(g.shopRng(), weights); {
+	case pick < 0:
+		return ShopItem{}, false
+	case shopCardTypes[pick] == ShopCardPlanet:
+		planet := planetCards[g.shopRng().Intn(len(planetCards))]
+		planet.Price = g.shopPrice(planet.Price)
+		return ShopItem{Consumable: planet}, true
+	default:
+		joker := applyStakeStickers(g.shopRng(), g.stake, []OwnedJoker{newOwnedJoker(rollJokerByRarity(g.shopRng(), jokers, config.RarityWeights))})[0]
+		joker = applyEdition(g.shopRng(), joker)
+		joker.PurchasePrice = g.shopPrice(joker.PurchasePrice)
+		return ShopItem{Joker: joker}, true
+	}
+}
+
+// shopJokerCandidates returns the jokers that may appear in the shop: those
+// whose rarity has a weight and, unless duplicates are allowed, that the
+// player doesn't own and aren't already on offer
+func (g *Game) shopJokerCandidates(rolled []ShopItem) []Joker {
+	config := GetShopConfig()
+	var candidates []Joker
+	for _, joker := range GetAvailableJokers() {
+		if config.RarityWeights[joker.Rarity] <= 0 {
+			continue
+		}
+		if !config.AllowDuplicates {
+			if PlayerHasJoker(g.jokers, joker.Name) || shopHasJoker(rolled, joker.Name) {
+				continue
+			}
+		}
+		candidates = append(candidates, joker)
+	}
+	return candidates
+}
+
+// rollJoker picks a joker the player could be offered, using the given
+// rarity weights. It reports false when no joker has a weighted rarity.
+func (g *Game) rollJoker(rolled []ShopItem, rarityWeights map[string]int) (Joker, bool) {
+	var candidates []Joker
+	for _, joker := range g.shopJokerCandidates(rolled) {
+		if rarityWeights[joker.Rarity] > 0 {
+			candidates = append(candidates, joker)
+		}
+	}
+	if len(candidates) == 0 {
+		return Joker{}, false
+	}
+	return rollJokerByRarity(g.shopRng(), candidates, rarityWeights), true
+}
+
+func shopHasJoker(items []ShopItem, name string) bool {
+	for _, item := range items {
+		if item.Joker.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// rollJokerByRarity picks a rarity by weight among those with candidates,
+// then a joker of that rarity uniformly
+func rollJokerByRarity(r *rand.Rand, candidates []Joker, rarityWeights map[string]int) Joker {
+	byRarity := make(map[string][]Joker)
+	for _, joker := range candidates {
+		byRarity[joker.Rarity] = append(byRarity[joker.Rarity], joker)
+	}
+
+	weights := make([]int, len(Rarities))
+	for i, rarity := range Rarities {
+		if len(byRarity[rarity]) > 0 {
+			weights[i] = rarityWeights[rarity]
+		}
+	}
+	pool := byRarity[Rarities[weightedChoice(r, weights)]]
+	return pool[r.Intn(len(pool))]
+}
+
+// weightedChoice returns an index chosen with probability proportional to its
+// weight, or -1 if no weight is positive
+func weightedChoice(r *rand.Rand, weights []int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	if total <= 0 {
+		return -1
+	}
+	roll := r.Intn(total)
+	for i, w := range weights {
+		if roll < w {
+			return i
+		}
+		roll -= w
+	}
+	return -1
+}
+
+// Reroll costs: the first reroll in each shop costs BaseRerollCost and every
+// reroll after that costs RerollCostIncrease more
+const (
+	BaseRerollCost     = 5
+	RerollCostIncrease = 2
+)
+
+// Shop is one visit to the shop between blinds: the cards, packs and voucher
+// for sale, what the next reroll costs and what the player has bought. Its
+// methods update the game and return the events describing what happened
+// rather than emitting them, so the caller decides how to present them.
+type Shop struct {
+	game *Game
+	// Items are the cards for sale; bought cards leave an empty slot
+	Items []ShopItem
+	// Packs are the booster packs for sale, numbered after Items. Rerolls
+	// don't replace them.
+	Packs []ShopItem
+	// Vouchers holds this ante's voucher, if it hasn't been bought,
+	// numbered after Packs
+	Vouchers   []ShopItem
+	RerollCost int
+	// Purchases are the items bought during this visit, in order
+	Purchases []ShopItem
+	// Pack is the booster pack being opened, if any
+	Pack   *OpenPack
+	closed bool
+}
+
+// shopRng returns the random source for the shop and for the cards jokers
+// and consumables create. It is seeded from the run seed, ante and blind, so
+// a seeded run or a reloaded save is offered the same shops.
+func (g *Game) shopRng() *rand.Rand {
+	if g.shopRand == nil {
+		g.shopRand = rand.New(rand.NewSource(GetSeed() + int64(g.currentAnte)*7 + int64(g.currentBlind)))
+	}
+	return g.shopRand
+}
+
+// newShop stocks a shop for the game and applies any held shop tags
+func (g *Game) newShop() *Shop {
+	g.shopRand = nil
+	shop := &Shop{
+		game:       g,
+		Items:      g.rollShopItems(),
+		Packs:      g.rollShopPacks(),
+		RerollCost: g.baseRerollCost(),
+	}
+	if v, ok := g.voucherForAnte(); ok {
+		shop.Vouchers = []ShopItem{{Voucher: &v, VoucherPrice: g.shopPrice(VoucherPrice)}}
+	}
+	g.applyShopTags(shop)
+	return shop
+}
+
+// Closed reports whether the player has left the shop
+func (s *Shop) Closed() bool {
+	return s.closed
+}
+
+// Opening reports whether the player is choosing cards from a booster pack
+func (s *Shop) Opening() bool {
+	return s.Pack != nil
+}
+
+// stock returns the cards, packs and voucher for sale, in slot order
+func (s *Shop) stock() []ShopItem {
+	var stock []ShopItem
+	stock = append(stock, s.Items...)
+	stock = append(stock, s.Packs...)
+	return append(stock, s.Vouchers...)
+}
+
+// slot returns the item in a slot numbered from 1, or nil if there is none
+func (s *Shop) slot(n int) *ShopItem {
+	for _, section := range [][]ShopItem{s.Items, s.Packs, s.Vouchers} {
+		if n >= 1 && n <= len(section) {
+			return &section[n-1]
+		}
+		n -= len(section)
+	}
+	return nil
+}
+
+// Open returns the events that show the shop's current stock
+func (s *Shop) Open() []Event {
+	events := []Event{ShopOpenedEvent{
+		Money:      s.game.money,
+		RerollCost: s.RerollCost,
+		Items:      shopItemData(s.stock(), s.game.money),
+	}}
+	if len(s.stock()) == 0 {
+		events = append(events, MessageEvent{Message: "Shop sold out!", Type: "info"})
+	}
+	return events
+}
+
+// Handle performs a shop action from the player and returns its events
+func (s *Shop) Handle(action PlayerAction, params []string) []Event {
+	if s.Opening() {
+		return s.handlePackAction(action, params)
+	}
+
+	switch action {
+	case PlayerActionBuy:
+		slot, ok := parseIndexParam(params)
+		if !ok {
+			return []Event{InvalidActionEvent{
+				Action: "buy",
+				Reason: fmt.Sprintf("Invalid item number (given %v).", params),
+			}}
+		}
+		return s.Buy(slot)
+	case PlayerActionReroll:
+		return s.Reroll()
+	case PlayerActionSellJoker:
+		idx, ok := parseIndexParam(params)
+		if !ok {
+			return []Event{InvalidActionEvent{
+				Action: "sell_joker",
+				Reason: "Usage: sell_joker <index>",
+			}}
+		}
+		return s.Sell(idx)
+	case PlayerActionExitShop:
+		return s.Exit()
+	default:
+		return []Event{InvalidActionEvent{
+			Action: "unknown",
+			Reason: fmt.Sprintf("Invalid action (given '%s'). Use 'buy <number>', 'reroll', or 'exit'.", action),
+		}}
+	}
+}
+
+// parseIndexParam reads a single numeric parameter
+func parseIndexParam(params []string) (int, bool) {
+	if len(params) != 1 {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(params[0])
+	return idx, err == nil
+}
+
+// Buy pays for the item in a slot (numbered from 1) and gives it to the
+// player. Booster packs are opened straight away.
+func (s *Shop) Buy(slot int) []Event {
+	g := s.game
+	selected := s.slot(slot)
+	if selected == nil {
+		return []Event{InvalidActionEvent{
+			Action: "buy",
+			Reason: fmt.Sprintf("Invalid item number (given %d).", slot),
+		}}
+	}
+	item := *selected
+	if item.Empty() {
+		return []Event{InvalidActionEvent{
+			Action: "buy",
+			Reason: "That slot is empty!",
+		}}
+	}
+	if g.money < item.Price() {
+		return []Event{InvalidActionEvent{
+			Action: "buy",
+			Reason: fmt.Sprintf("Not enough money! Need $%d more.", item.Price()-g.money),
+		}}
+	}
+	if item.IsConsumable() && len(g.consumables) >= g.consumableSlots() {
+		return []Event{InvalidActionEvent{
+			Action: "buy",
+			Reason: fmt.Sprintf("No room for %s! Consumable slots are full (%d/%d).", item.Name(), len(g.consumables), g.consumableSlots()),
+		}}
+	}
+	if item.IsJoker() && !g.hasJokerRoom(item.Joker) {
+		return []Event{InvalidActionEvent{Action: "buy", Reason: g.noJokerRoomReason(item.Name())}}
+	}
+
+	bought := item.Data(g.money)
+	g.money -= item.Price()
+	*selected = ShopItem{}
+	s.Purchases = append(s.Purchases, item)
+
+	var redeemed []Event
+	switch {
+	case item.IsVoucher():
+		redeemed = append(redeemed, g.redeemVoucher(*item.Voucher))
+		s.applyVoucher(*item.Voucher)
+	case item.IsPack():
+		s.Pack = g.openPack(*item.Pack)
+	case item.IsConsumable():
+		g.consumables = append(g.consumables, item.Consumable)
+	default:
+		g.jokers = append(g.jokers, item.Joker)
+	}
+
+	events := []Event{
+		ShopItemPurchasedEvent{
+			Item:           bought,
+			RemainingMoney: g.money,
+			Items:          shopItemData(s.stock(), g.money),
+		},
+		g.gameStateEvent(),
+	}
+	events = append(events, redeemed...)
+	if s.Opening() {
+		events = append(events, s.Pack.openedEvent())
+	}
+	return events
+}
+
+// applyVoucher updates this shop for a voucher just redeemed in it
+func (s *Shop) applyVoucher(v Voucher) {
+	g := s.game
+	switch v.Effect {
+	case VoucherShopSlots:
+		for i := 0; i < v.Amount; i++ {
+			item, ok := g.rollShopItem(s.Items)
+			if !ok {
+				break
+			}
+			s.Items = append(s.Items, item)
+		}
+	case VoucherRerollDiscount:
+		s.RerollCost -= v.Amount
+		if s.RerollCost < 0 {
+			s.RerollCost = 0
+		}
+	}
+}
+
+// Reroll pays the reroll cost to replace the shop's stock
+func (s *Shop) Reroll() []Event {
+	g := s.game
+	if g.money < s.RerollCost {
+		return []Event{InvalidActionEvent{
+			Action: "reroll",
+			Reason: fmt.Sprintf("Not enough money to reroll! Need $%d more.", s.RerollCost-g.money),
+		}}
+	}
+
+	cost := s.RerollCost
+	g.money -= cost
+	s.RerollCost += RerollCostIncrease
+	s.Items = g.rollShopItems()
+
+	return []Event{ShopRerolledEvent{
+		Cost:           cost,
+		NewRerollCost:  s.RerollCost,
+		RemainingMoney: g.money,
+		NewItems:       shopItemData(s.stock(), g.money),
+	}}
+}
+
+// Sell sells one of the player's jokers (numbered from 1)
+func (s *Shop) Sell(joker int) []Event {
+	events, _ := s.game.sellJoker(joker)
+	return events
+}
+
+// Exit closes the shop
+func (s *Shop) Exit() []Event {
+	s.closed = true
+	return []Event{ShopClosedEvent{}}
+}
+
+// handlePackAction performs an action while a booster pack is open
+func (s *Shop) handlePackAction(action PlayerAction, params []string) []Event {
+	switch action {
+	case PlayerActionNone:
+		return nil
+	case PlayerActionPickCard:
+		idx, ok := parseIndexParam(params)
+		if !ok {
+			return []Event{InvalidActionEvent{
+				Action: "pick_card",
+				Reason: fmt.Sprintf("Invalid card number (given %v).", params),
+			}}
+		}
+		return s.Pick(idx)
+	case PlayerActionSkipPack:
+		return s.SkipPack()
+	default:
+		return []Event{InvalidActionEvent{
+			Action: string(action),
+			Reason: "Pick a card from the pack or skip it first.",
+		}}
+	}
+}
+
+// Pick takes a card (numbered from 1) from the open booster pack, closing
+// the pack once the player has no picks left
+func (s *Shop) Pick(card int) []Event {
+	pack := s.Pack
+	if pack == nil {
+		return []Event{InvalidActionEvent{Action: "pick_card", Reason: "No booster pack is open."}}
+	}
+	if card < 1 || card > len(pack.Cards) || pack.Cards[card-1].Empty() {
+		return []Event{InvalidActionEvent{
+			Action: "pick_card",
+			Reason: fmt.Sprintf("Invalid card number (given %d).", card),
+		}}
+	}
+
+	picked := pack.Cards[card-1]
+	if reason := s.game.packCardBlocked(picked); reason != "" {
+		return []Event{InvalidActionEvent{Action: "pick_card", Reason: reason}}
+	}
+	pack.Cards[card-1] = PackCard{}
+	pack.PicksLeft--
+
+	events := []Event{PackCardPickedEvent{
+		Card:      picked.Data(),
+		Cards:     packCardData(pack.Cards),
+		PicksLeft: pack.PicksLeft,
+	}}
+	events = append(events, s.game.takePackCard(picked)...)
+	events = append(events, s.game.gameStateEvent())
+
+	remaining := 0
+	for _, c := range pack.Cards {
+		if !c.Empty() {
+			remaining++
+		}
+	}
+	if pack.PicksLeft == 0 || remaining == 0 {
+		events = append(events, s.SkipPack()...)
+	}
+	return events
+}
+
+// SkipPack discards the rest of the open booster pack
+func (s *Shop) SkipPack() []Event {
+	if s.Pack == nil {
+		return []Event{InvalidActionEvent{Action: "skip_pack", Reason: "No booster pack is open."}}
+	}
+	name := s.Pack.Pack.Name()
+	s.Pack = nil
+	return []Event{PackClosedEvent{Pack: name}}
+}
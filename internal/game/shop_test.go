@@ -1,6 +1,11 @@
 package game
 
-import "testing"
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
 
 // Mock scanner for testing
 type MockScanner struct {
@@ -27,9 +32,9 @@ func (m *MockScanner) Text() string {
 // Helper function to create a test game with mock scanner
 func createTestGame(inputs []string) *Game {
 	game := &Game{
-		money:      20, // Start with enough money for testing
-		rerollCost: 5,
-		jokers:     []Joker{},
+		money:        20, // Start with enough money for testing
+		jokers:       []OwnedJoker{},
+		eventEmitter: NewEventEmitter(),
 	}
 	return game
 }
@@ -46,7 +51,7 @@ func getTestJokers() []Joker {
 }
 
 // Helper function to simulate shop item selection
-func selectShopItems(availableJokers []Joker, playerJokers []Joker, seed int64) []Joker {
+func selectShopItems(availableJokers []Joker, playerJokers []OwnedJoker, seed int64) []Joker {
 	// Filter jokers player doesn't own
 	var candidates []Joker
 	for _, joker := range availableJokers {
@@ -81,7 +86,7 @@ func selectShopItems(availableJokers []Joker, playerJokers []Joker, seed int64)
 
 func TestShopItemSelection(t *testing.T) {
 	availableJokers := getTestJokers()
-	playerJokers := []Joker{}
+	playerJokers := []OwnedJoker{}
 
 	// Test that we get 2 items when available
 	shopItems := selectShopItems(availableJokers, playerJokers, 0)
@@ -105,9 +110,9 @@ func TestShopItemSelection(t *testing.T) {
 
 func TestShopItemSelectionWithOwnedJokers(t *testing.T) {
 	availableJokers := getTestJokers()
-	playerJokers := []Joker{
-		{Name: "Test Joker 1", Price: 5},
-		{Name: "Test Joker 2", Price: 6},
+	playerJokers := []OwnedJoker{
+		{Joker: Joker{Name: "Test Joker 1", Price: 5}, PurchasePrice: 5},
+		{Joker: Joker{Name: "Test Joker 2", Price: 6}, PurchasePrice: 6},
 	}
 
 	shopItems := selectShopItems(availableJokers, playerJokers, 0)
@@ -127,32 +132,23 @@ func TestShopItemSelectionWithOwnedJokers(t *testing.T) {
 
 func TestRerollCostProgression(t *testing.T) {
 	game := createTestGame([]string{})
+	shop := game.newShop()
 
 	// Initial reroll cost should be 5
-	if game.rerollCost != 5 {
-		t.Errorf("Expected initial reroll cost to be 5, got %d", game.rerollCost)
-	}
-
-	// Simulate reroll
-	if game.money >= game.rerollCost {
-		game.money -= game.rerollCost
-		game.rerollCost += 2
+	if shop.RerollCost != 5 {
+		t.Errorf("Expected initial reroll cost to be 5, got %d", shop.RerollCost)
 	}
 
 	// After first reroll, cost should be 7
-	if game.rerollCost != 7 {
-		t.Errorf("Expected reroll cost to be 7 after first reroll, got %d", game.rerollCost)
-	}
-
-	// Simulate another reroll
-	if game.money >= game.rerollCost {
-		game.money -= game.rerollCost
-		game.rerollCost += 2
+	shop.Reroll()
+	if shop.RerollCost != 7 || game.money != 15 {
+		t.Errorf("Expected reroll cost 7 and $15 after first reroll, got %d and $%d", shop.RerollCost, game.money)
 	}
 
 	// After second reroll, cost should be 9
-	if game.rerollCost != 9 {
-		t.Errorf("Expected reroll cost to be 9 after second reroll, got %d", game.rerollCost)
+	shop.Reroll()
+	if shop.RerollCost != 9 || game.money != 8 {
+		t.Errorf("Expected reroll cost 9 and $8 after second reroll, got %d and $%d", shop.RerollCost, game.money)
 	}
 }
 
@@ -160,33 +156,28 @@ func TestRerollCostReset(t *testing.T) {
 	game := createTestGame([]string{})
 
 	// Increase reroll cost
-	game.rerollCost = 8
-
-	// Simulate starting new blind (reset logic)
-	game.rerollCost = 5
+	game.newShop().Reroll()
 
-	// Should be back to 5
-	if game.rerollCost != 5 {
-		t.Errorf("Expected reroll cost to reset to 5 for new blind, got %d", game.rerollCost)
+	// Each new shop starts back at 5
+	if shop := game.newShop(); shop.RerollCost != 5 {
+		t.Errorf("Expected reroll cost to reset to 5 for new blind, got %d", shop.RerollCost)
 	}
 }
 
 func TestCanAffordReroll(t *testing.T) {
 	game := createTestGame([]string{})
-
-	// Player has $20, reroll costs $5 - should be able to afford
-	canAfford := game.money >= game.rerollCost
-	if !canAfford {
-		t.Errorf("Player with $%d should be able to afford reroll costing $%d", game.money, game.rerollCost)
-	}
-
-	// Reduce money below reroll cost
 	game.money = 3
-	game.rerollCost = 5
+	shop := &Shop{game: game, RerollCost: 5}
 
-	canAfford = game.money >= game.rerollCost
-	if canAfford {
-		t.Errorf("Player with $%d should NOT be able to afford reroll costing $%d", game.money, game.rerollCost)
+	events := shop.Reroll()
+	if len(events) != 1 {
+		t.Fatalf("Expected a single event, got %v", events)
+	}
+	if _, ok := events[0].(InvalidActionEvent); !ok {
+		t.Errorf("Player with $%d should NOT be able to afford reroll costing $%d", game.money, shop.RerollCost)
+	}
+	if game.money != 3 || shop.RerollCost != 5 {
+		t.Errorf("Expected a failed reroll to change nothing, money=%d cost=%d", game.money, shop.RerollCost)
 	}
 }
 
@@ -194,16 +185,16 @@ func TestShopPurchase(t *testing.T) {
 	game := createTestGame([]string{})
 	initialMoney := game.money
 
-	jokerToBuy := Joker{Name: "Test Purchase", Price: 6, Description: "Test"}
+	jokerToBuy := OwnedJoker{Joker: Joker{Name: "Test Purchase", Price: 6, Description: "Test"}, PurchasePrice: 6}
 
 	// Simulate purchase
-	if game.money >= jokerToBuy.Price {
-		game.money -= jokerToBuy.Price
+	if game.money >= jokerToBuy.PurchasePrice {
+		game.money -= jokerToBuy.PurchasePrice
 		game.jokers = append(game.jokers, jokerToBuy)
 	}
 
 	// Check money was deducted
-	expectedMoney := initialMoney - jokerToBuy.Price
+	expectedMoney := initialMoney - jokerToBuy.PurchasePrice
 	if game.money != expectedMoney {
 		t.Errorf("Expected money to be %d after purchase, got %d", expectedMoney, game.money)
 	}
@@ -219,9 +210,9 @@ func TestShopPurchase(t *testing.T) {
 }
 
 func TestPlayerHasJoker(t *testing.T) {
-	playerJokers := []Joker{
-		{Name: "Owned Joker 1"},
-		{Name: "Owned Joker 2"},
+	playerJokers := []OwnedJoker{
+		{Joker: Joker{Name: "Owned Joker 1"}},
+		{Joker: Joker{Name: "Owned Joker 2"}},
 	}
 
 	// Test owned joker
@@ -235,7 +226,7 @@ func TestPlayerHasJoker(t *testing.T) {
 	}
 
 	// Test empty joker list
-	if PlayerHasJoker([]Joker{}, "Any Joker") {
+	if PlayerHasJoker([]OwnedJoker{}, "Any Joker") {
 		t.Errorf("PlayerHasJoker should return false for empty joker list")
 	}
 }
@@ -247,9 +238,9 @@ func TestShopWithNoAvailableJokers(t *testing.T) {
 	}
 
 	// Player owns all available jokers
-	playerJokers := []Joker{
-		{Name: "Joker 1", Price: 5},
-		{Name: "Joker 2", Price: 6},
+	playerJokers := []OwnedJoker{
+		{Joker: Joker{Name: "Joker 1", Price: 5}, PurchasePrice: 5},
+		{Joker: Joker{Name: "Joker 2", Price: 6}, PurchasePrice: 6},
 	}
 
 	shopItems := selectShopItems(availableJokers, playerJokers, 0)
@@ -267,8 +258,8 @@ func TestShopWithOneAvailableJoker(t *testing.T) {
 	}
 
 	// Player owns all but one joker
-	playerJokers := []Joker{
-		{Name: "Joker 1", Price: 5},
+	playerJokers := []OwnedJoker{
+		{Joker: Joker{Name: "Joker 1", Price: 5}, PurchasePrice: 5},
 	}
 
 	shopItems := selectShopItems(availableJokers, playerJokers, 0)
@@ -283,3 +274,234 @@ func TestShopWithOneAvailableJoker(t *testing.T) {
 		t.Errorf("Expected shop to offer Joker 2, got %s", shopItems[0].Name)
 	}
 }
+
+// withShopConfig swaps in a shop configuration for the duration of a test.
+func withShopConfig(t *testing.T, config ShopConfig) {
+	old := shopConfig
+	shopConfig = config
+	t.Cleanup(func() { shopConfig = old })
+}
+
+// TestRollShopItemsSkipsOwnedJokers verifies rolls fill every slot without repeating owned or offered jokers.
+func TestRollShopItemsSkipsOwnedJokers(t *testing.T) {
+	setDefaultJokerConfigs()
+	withShopConfig(t, ShopConfig{
+		Slots:         3,
+		CardWeights:   map[string]int{ShopCardJoker: 1},
+		RarityWeights: map[string]int{RarityCommon: 1, RarityUncommon: 1, RarityRare: 1},
+	})
+	golden, _ := GetJokerByName("The Golden Joker")
+	owned := newOwnedJoker(golden)
+	g := &Game{jokers: []OwnedJoker{owned}}
+
+	for i := 0; i < 50; i++ {
+		items := g.rollShopItems()
+		if len(items) != 3 {
+			t.Fatalf("expected 3 items, got %d", len(items))
+		}
+		seen := map[string]bool{}
+		for _, item := range items {
+			if item.IsConsumable() || item.Joker.Name == owned.Name || seen[item.Joker.Name] {
+				t.Fatalf("unexpected item in roll %v", items)
+			}
+			seen[item.Joker.Name] = true
+		}
+	}
+}
+
+// TestRollShopItemsAllowsDuplicates verifies owned jokers can appear when duplicates are allowed.
+func TestRollShopItemsAllowsDuplicates(t *testing.T) {
+	setDefaultJokerConfigs()
+	withShopConfig(t, ShopConfig{
+		Slots:           4,
+		AllowDuplicates: true,
+		CardWeights:     map[string]int{ShopCardJoker: 1},
+		RarityWeights:   map[string]int{RarityCommon: 1},
+	})
+	g := &Game{}
+	for _, joker := range GetAvailableJokers() {
+		g.jokers = append(g.jokers, newOwnedJoker(joker))
+	}
+
+	items := g.rollShopItems()
+	if len(items) != 4 {
+		t.Fatalf("expected owned jokers to fill all 4 slots, got %v", items)
+	}
+	for _, item := range items {
+		if item.Joker.Rarity != RarityCommon {
+			t.Fatalf("expected only Common jokers, got %s", item.Joker.Rarity)
+		}
+	}
+
+	shopConfig.AllowDuplicates = false
+	if items := g.rollShopItems(); len(items) != 0 {
+		t.Fatalf("expected nothing to offer without duplicates, got %v", items)
+	}
+}
+
+// TestRollJokerByRarity verifies rarities without weight are never rolled.
+func TestRollJokerByRarity(t *testing.T) {
+	candidates := []Joker{
+		{Name: "C", Rarity: RarityCommon},
+		{Name: "U", Rarity: RarityUncommon},
+		{Name: "R", Rarity: RarityRare},
+	}
+	for i := 0; i < 50; i++ {
+		if got := rollJokerByRarity(rand.New(rand.NewSource(int64(i))), candidates, map[string]int{RarityRare: 1}); got.Name != "R" {
+			t.Fatalf("expected only the Rare joker, got %s", got.Name)
+		}
+	}
+}
+
+// TestRollShopItemsMixesConsumables verifies planet cards are offered by weight.
+func TestRollShopItemsMixesConsumables(t *testing.T) {
+	setDefaultJokerConfigs()
+	withShopConfig(t, ShopConfig{
+		Slots:         2,
+		CardWeights:   map[string]int{ShopCardJoker: 0, ShopCardPlanet: 1},
+		RarityWeights: map[string]int{RarityCommon: 1},
+	})
+	g := &Game{}
+
+	for _, item := range g.rollShopItems() {
+		if !item.IsConsumable() || item.Consumable.Type != PlanetCard {
+			t.Fatalf("expected only planet cards, got %v", item)
+		}
+		if data := item.Data(10); data.Type != "planet" || data.Description == "" {
+			t.Fatalf("unexpected shop data %+v", data)
+		}
+	}
+}
+
+// TestShopFollowsSeed verifies a shop's cards and packs come from the run
+// seed, so a seeded run or a reloaded save is offered the same shop.
+func TestShopFollowsSeed(t *testing.T) {
+	setDefaultJokerConfigs()
+	withShopConfig(t, ShopConfig{
+		Slots:         4,
+		CardWeights:   map[string]int{ShopCardJoker: 1, ShopCardPlanet: 1},
+		RarityWeights: map[string]int{RarityCommon: 1, RarityUncommon: 1},
+	})
+	SetSeed(42)
+	stock := func() []string {
+		g := &Game{currentAnte: 2, currentBlind: BigBlind}
+		shop := g.newShop()
+		rand.Seed(time.Now().UnixNano())
+		var names []string
+		for _, item := range append(shop.Items, shop.Packs...) {
+			names = append(names, item.Data(0).Name)
+		}
+		return names
+	}
+	first, second := stock(), stock()
+	if strings.Join(first, ",") != strings.Join(second, ",") {
+		t.Fatalf("expected the same shop for the same seed, got %v and %v", first, second)
+	}
+}
+
+// TestParseShopYAML verifies shop configs are validated.
+func TestParseShopYAML(t *testing.T) {
+	valid := "slots: 3\ncard_weights:\n  joker: 1\nrarity_weights:\n  Common: 1\n"
+	config, err := parseShopYAML([]byte(valid))
+	if err != nil || config.Slots != 3 {
+		t.Fatalf("expected valid config, got %+v, %v", config, err)
+	}
+
+	invalid := []string{
+		"slots: 0\ncard_weights:\n  joker: 1\nrarity_weights:\n  Common: 1\n",
+		"slots: 2\ncard_weights:\n  tarot: 1\nrarity_weights:\n  Common: 1\n",
+		"slots: 2\ncard_weights:\n  joker: 0\nrarity_weights:\n  Common: 1\n",
+		"slots: 2\ncard_weights:\n  joker: 1\nrarity_weights:\n  Common: -1\n",
+	}
+	for _, data := range invalid {
+		if _, err := parseShopYAML([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+// TestBuyAndUseConsumable verifies planet cards are held until used and respect slot limits.
+func TestBuyAndUseConsumable(t *testing.T) {
+	LoadConfig()
+	handler := &testEventHandler{}
+	g := &Game{money: 20, handLevels: map[string]int{"Pair": 1}, eventEmitter: NewEventEmitter()}
+	g.eventEmitter.SetEventHandler(handler)
+	mercury, _ := GetConsumableByName("Mercury")
+
+	shop := &Shop{game: g}
+	for i := 0; i <= DefaultConsumableSlots; i++ {
+		shop.Items = append(shop.Items, ShopItem{Consumable: mercury})
+	}
+	for i := 1; i <= DefaultConsumableSlots; i++ {
+		if _, ok := shop.Buy(i)[0].(ShopItemPurchasedEvent); !ok {
+			t.Fatalf("expected purchase %d to succeed", i)
+		}
+	}
+	if _, ok := shop.Buy(DefaultConsumableSlots + 1)[0].(InvalidActionEvent); !ok {
+		t.Fatalf("expected purchase to fail with full consumable slots")
+	}
+	if len(shop.Purchases) != DefaultConsumableSlots {
+		t.Fatalf("expected %d purchases recorded, got %v", DefaultConsumableSlots, shop.Purchases)
+	}
+	if g.money != 20-DefaultConsumableSlots*PlanetPrice {
+		t.Fatalf("unexpected money %d", g.money)
+	}
+
+	g.handleUseConsumableAction([]string{"1"})
+	if g.handLevels["Pair"] != 2 || len(g.consumables) != DefaultConsumableSlots-1 {
+		t.Fatalf("expected Mercury to level up Pair, levels=%v consumables=%v", g.handLevels, g.consumables)
+	}
+}
+
+// TestShopBuy verifies buying empties the slot, records the purchase and
+// reports the remaining stock.
+func TestShopBuy(t *testing.T) {
+	g := createTestGame([]string{})
+	shop := &Shop{game: g, Items: []ShopItem{{Joker: OwnedJoker{Joker: Joker{Name: "J1", Price: 5}, PurchasePrice: 5}}, {Joker: OwnedJoker{Joker: Joker{Name: "J2", Price: 30}, PurchasePrice: 30}}}}
+
+	events := shop.Buy(1)
+	purchased, ok := events[0].(ShopItemPurchasedEvent)
+	if !ok {
+		t.Fatalf("expected a purchase event, got %v", events)
+	}
+	if purchased.Item.Name != "J1" || purchased.RemainingMoney != 15 || purchased.Items[0].Name != "" || purchased.Items[1].Name != "J2" {
+		t.Fatalf("unexpected purchase event %+v", purchased)
+	}
+	if _, ok := events[1].(GameStateChangedEvent); !ok {
+		t.Fatalf("expected a game state event after the purchase, got %v", events)
+	}
+	if len(g.jokers) != 1 || len(shop.Purchases) != 1 || !shop.Items[0].Empty() {
+		t.Fatalf("expected J1 to move from the shop to the player, jokers=%v items=%v", g.jokers, shop.Items)
+	}
+
+	// Empty slots, unaffordable items and bad slot numbers are rejected
+	for _, slot := range []int{1, 2, 0, 3} {
+		if _, ok := shop.Buy(slot)[0].(InvalidActionEvent); !ok {
+			t.Errorf("expected buying slot %d to fail", slot)
+		}
+	}
+	if g.money != 15 {
+		t.Fatalf("expected failed purchases to cost nothing, money=%d", g.money)
+	}
+}
+
+// TestShopHandle verifies player actions are parsed and the shop closes on exit.
+func TestShopHandle(t *testing.T) {
+	g := createTestGame([]string{})
+	g.jokers = []OwnedJoker{{Joker: Joker{Name: "J1", Price: 6}, PurchasePrice: 6}}
+	shop := &Shop{game: g}
+
+	if _, ok := shop.Handle(PlayerActionBuy, nil)[0].(InvalidActionEvent); !ok {
+		t.Fatalf("expected buy without a slot to be rejected")
+	}
+	shop.Handle(PlayerActionSellJoker, []string{"1"})
+	if len(g.jokers) != 0 || g.money != 23 {
+		t.Fatalf("expected J1 to sell for $3, jokers=%v money=%d", g.jokers, g.money)
+	}
+	if shop.Closed() {
+		t.Fatalf("shop should stay open until exit")
+	}
+	if _, ok := shop.Handle(PlayerActionExitShop, nil)[0].(ShopClosedEvent); !ok || !shop.Closed() {
+		t.Fatalf("expected exit to close the shop")
+	}
+}
@@ -0,0 +1,209 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Stake is a difficulty level; each stake includes every modifier of the
+// stakes below it
+type Stake int
+
+const (
+	// WhiteStake is the base difficulty
+	WhiteStake Stake = iota
+	// RedStake makes the Small Blind give no reward money
+	RedStake
+	// GreenStake makes required scores scale faster
+	GreenStake
+	// BlackStake lets shop jokers be Eternal
+	BlackStake
+	// BlueStake removes one discard per blind
+	BlueStake
+	// PurpleStake makes required scores scale faster still
+	PurpleStake
+	// OrangeStake lets shop jokers be Perishable
+	OrangeStake
+	// GoldStake lets shop jokers be Rental
+	GoldStake
+)
+
+// Sticker chances and costs applied to shop jokers on higher stakes
+const (
+	StickerChance      = 0.3
+	PerishableRounds   = 5
+	RentalPrice        = 1
+	RentalSellValue    = 1
+	RentalCostPerRound = 3
+	greenStakeScaling  = 0.15
+	purpleStakeScaling = 0.3
+	maxStake           = GoldStake
+)
+
+func (s Stake) String() string {
+	switch s {
+	case WhiteStake:
+		return "White Stake"
+	case RedStake:
+		return "Red Stake"
+	case GreenStake:
+		return "Green Stake"
+	case BlackStake:
+		return "Black Stake"
+	case BlueStake:
+		return "Blue Stake"
+	case PurpleStake:
+		return "Purple Stake"
+	case OrangeStake:
+		return "Orange Stake"
+	case GoldStake:
+		return "Gold Stake"
+	default:
+		return "Unknown"
+	}
+}
+
+// Description returns the modifier this stake adds on top of lower stakes
+func (s Stake) Description() string {
+	switch s {
+	case WhiteStake:
+		return "Base difficulty"
+	case RedStake:
+		return "Small Blind gives no reward money"
+	case GreenStake:
+		return "Required score scales faster for each Ante"
+	case BlackStake:
+		return "Shop can have Eternal Jokers (can't be sold)"
+	case BlueStake:
+		return "-1 Discard"
+	case PurpleStake:
+		return "Required score scales even faster for each Ante"
+	case OrangeStake:
+		return "Shop can have Perishable Jokers (debuffed after 5 Rounds)"
+	case GoldStake:
+		return "Shop can have Rental Jokers (cost $3 per Round)"
+	default:
+		return ""
+	}
+}
+
+// AllStakes returns every stake from lowest to highest
+func AllStakes() []Stake {
+	stakes := make([]Stake, 0, int(maxStake)+1)
+	for s := WhiteStake; s <= maxStake; s++ {
+		stakes = append(stakes, s)
+	}
+	return stakes
+}
+
+// ParseStake accepts a stake name such as "gold" or "Gold Stake"
+func ParseStake(name string) (Stake, error) {
+	if name == "" {
+		return WhiteStake, nil
+	}
+	normalized := strings.ToLower(strings.TrimSpace(name))
+	normalized = strings.TrimSuffix(normalized, " stake")
+	for _, s := range AllStakes() {
+		if strings.ToLower(strings.TrimSuffix(s.String(), " Stake")) == normalized {
+			return s, nil
+		}
+	}
+	return WhiteStake, fmt.Errorf("unknown stake %q", name)
+}
+
+// SmallBlindPaysReward reports whether defeating the Small Blind pays money
+func (s Stake) SmallBlindPaysReward() bool {
+	return s < RedStake
+}
+
+// DiscardModifier returns the change to discards per blind
+func (s Stake) DiscardModifier() int {
+	if s >= BlueStake {
+		return -1
+	}
+	return 0
+}
+
+// ScaleTarget scales a blind's base score requirement for the given ante
+func (s Stake) ScaleTarget(ante, target int) int {
+	scaling := 0.0
+	switch {
+	case s >= PurpleStake:
+		scaling = purpleStakeScaling
+	case s >= GreenStake:
+		scaling = greenStakeScaling
+	}
+	if scaling == 0 || ante <= 1 {
+		return target
+	}
+	return int(ScoreFromFloat(float64(target) * (1 + scaling*float64(ante-1))))
+}
+
+// JokerSticker marks a shop joker with an extra rule from higher stakes
+type JokerSticker string
+
+const (
+	NoSticker         JokerSticker = ""
+	EternalSticker    JokerSticker = "Eternal"
+	PerishableSticker JokerSticker = "Perishable"
+	RentalSticker     JokerSticker = "Rental"
+)
+
+// applyStakeStickers randomly adds the stickers this stake allows to shop
+// jokers, rolling with r
+func applyStakeStickers(r *rand.Rand, stake Stake, jokers []OwnedJoker) []OwnedJoker {
+	stickered := make([]OwnedJoker, len(jokers))
+	copy(stickered, jokers)
+	for i := range stickered {
+		if stickered[i].Name == "" {
+			continue
+		}
+		switch {
+		case stake >= BlackStake && r.Float64() < StickerChance:
+			stickered[i].Sticker = EternalSticker
+		case stake >= OrangeStake && r.Float64() < StickerChance:
+			stickered[i].Sticker = PerishableSticker
+			stickered[i].RoundsLeft = PerishableRounds
+		case stake >= GoldStake && r.Float64() < StickerChance:
+			stickered[i].Sticker = RentalSticker
+			stickered[i].PurchasePrice = RentalPrice
+		}
+	}
+	return stickered
+}
+
+// StickerLabel returns a short tag describing the joker's edition, sticker
+// and debuff state, or an empty string when it has none. Face-down jokers
+// show nothing.
+func (j OwnedJoker) StickerLabel() string {
+	if j.FaceDown {
+		return ""
+	}
+	label := ""
+	if j.Edition != NoEdition {
+		label = fmt.Sprintf("[%s] ", j.Edition)
+	}
+	switch j.Sticker {
+	case EternalSticker:
+		label += "[Eternal]"
+	case PerishableSticker:
+		label += fmt.Sprintf("[Perishable: %d rounds]", j.RoundsLeft)
+	case RentalSticker:
+		label += fmt.Sprintf("[Rental: $%d/round]", RentalCostPerRound)
+	}
+	label = strings.TrimSuffix(label, " ")
+	if j.Debuffed {
+		if label != "" {
+			label += " "
+		}
+		label += "[Debuffed]"
+	}
+	if j.Disabled {
+		if label != "" {
+			label += " "
+		}
+		label += "[Disabled]"
+	}
+	return label
+}
@@ -0,0 +1,127 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestParseStake verifies stake names are parsed case-insensitively.
+func TestParseStake(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Stake
+	}{
+		{"", WhiteStake},
+		{"gold", GoldStake},
+		{"Blue Stake", BlueStake},
+		{"PURPLE", PurpleStake},
+	}
+	for _, tt := range tests {
+		got, err := ParseStake(tt.name)
+		if err != nil || got != tt.expected {
+			t.Errorf("ParseStake(%q) = %v, %v; want %v", tt.name, got, err, tt.expected)
+		}
+	}
+	if _, err := ParseStake("platinum"); err == nil {
+		t.Errorf("expected error for unknown stake")
+	}
+}
+
+// TestStakeModifiersStack verifies higher stakes include lower stake modifiers.
+func TestStakeModifiersStack(t *testing.T) {
+	if !WhiteStake.SmallBlindPaysReward() || GoldStake.SmallBlindPaysReward() {
+		t.Fatalf("expected only stakes below Red to pay the Small Blind reward")
+	}
+	if BlackStake.DiscardModifier() != 0 || BlueStake.DiscardModifier() != -1 || GoldStake.DiscardModifier() != -1 {
+		t.Fatalf("expected -1 discard from Blue Stake upwards")
+	}
+	if got := RedStake.ScaleTarget(3, 1000); got != 1000 {
+		t.Fatalf("expected Red Stake to leave targets alone, got %d", got)
+	}
+	green := GreenStake.ScaleTarget(3, 1000)
+	purple := PurpleStake.ScaleTarget(3, 1000)
+	if green <= 1000 || purple <= green {
+		t.Fatalf("expected Green and Purple to scale faster, got green=%d purple=%d", green, purple)
+	}
+	if got := GoldStake.ScaleTarget(1, 300); got != 300 {
+		t.Fatalf("expected no scaling at ante 1, got %d", got)
+	}
+}
+
+// TestRedStakeSmallBlindReward verifies the Small Blind pays no base reward on Red Stake.
+func TestRedStakeSmallBlindReward(t *testing.T) {
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:          NewDeck(),
+		currentAnte:   1,
+		currentBlind:  SmallBlind,
+		currentTarget: 300,
+		totalScore:    300,
+		stake:         RedStake,
+		eventEmitter:  NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handleBlindCompletion()
+
+	for _, e := range handler.events {
+		if d, ok := e.(BlindDefeatedEvent); ok {
+			if d.BaseReward != 0 {
+				t.Fatalf("expected no base reward on Red Stake, got %d", d.BaseReward)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected BlindDefeatedEvent to be emitted")
+}
+
+// TestStickerEffects verifies Eternal jokers can't be sold and rentals charge rent.
+func TestStickerEffects(t *testing.T) {
+	handler := &testEventHandler{}
+	g := &Game{
+		money: 10,
+		jokers: []OwnedJoker{
+			{Joker: Joker{Name: "Eternal", Price: 6}, PurchasePrice: 6, Sticker: EternalSticker},
+			{Joker: Joker{Name: "Rental", Price: 1}, PurchasePrice: 1, Sticker: RentalSticker},
+			{Joker: Joker{Name: "Perishable", Price: 4}, PurchasePrice: 4, Sticker: PerishableSticker, RoundsLeft: 1},
+		},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handleSellJokerAction([]string{"1"})
+	if len(g.jokers) != 3 || g.money != 10 {
+		t.Fatalf("expected Eternal joker sale to be rejected, jokers=%v money=%d", g.jokers, g.money)
+	}
+
+	if rent := g.endRoundStickers(g.money); len(rent) != 1 || rent[0].Amount != -RentalCostPerRound {
+		t.Fatalf("expected rent of %d for one joker, got %v", RentalCostPerRound, rent)
+	}
+	if !g.jokers[2].Debuffed {
+		t.Fatalf("expected Perishable joker to be debuffed after its last round")
+	}
+	if rent := g.endRoundStickers(1); rent[0].Amount != -1 {
+		t.Fatalf("expected rent to stop at $0, got %v", rent)
+	}
+	if got := g.jokers[1].SellValue(); got != RentalSellValue {
+		t.Fatalf("expected a Rental joker to sell for $%d, got $%d", RentalSellValue, got)
+	}
+}
+
+// TestStickersFollowSeed verifies sticker rolls come from the given source.
+func TestStickersFollowSeed(t *testing.T) {
+	jokers := make([]OwnedJoker, 20)
+	for i := range jokers {
+		jokers[i] = OwnedJoker{Joker: Joker{Name: "J"}}
+	}
+	roll := func() string {
+		stickers := ""
+		for _, joker := range applyStakeStickers(rand.New(rand.NewSource(9)), GoldStake, jokers) {
+			stickers += string(joker.Sticker) + ","
+		}
+		return stickers
+	}
+	if first, second := roll(), roll(); first != second {
+		t.Fatalf("expected the same stickers for the same seed, got %s and %s", first, second)
+	}
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// RunStats holds records kept across runs
+type RunStats struct {
+	HighestAnte int `json:"highest_ante"`
+}
+
+// statsPath is where run records are stored, alongside save files
+var statsPath = filepath.Join("saves", "stats.json")
+
+// LoadStats reads the stored run records, returning empty stats if none exist
+func LoadStats() (RunStats, error) {
+	var stats RunStats
+	data, err := os.ReadFile(statsPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// RecordHighestAnte stores ante if it beats the current record and returns
+// the previous record. The stats file is only written when the record
+// improves
+func RecordHighestAnte(ante int) (int, error) {
+	stats, err := LoadStats()
+	if err != nil {
+		return 0, err
+	}
+	previous := stats.HighestAnte
+	if ante <= previous {
+		return previous, nil
+	}
+
+	stats.HighestAnte = ante
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return previous, err
+	}
+	if err := os.MkdirAll(filepath.Dir(statsPath), 0755); err != nil {
+		return previous, err
+	}
+	return previous, os.WriteFile(statsPath, data, 0644)
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// TagType identifies the reward a Tag grants
+type TagType string
+
+const (
+	// D6Tag makes rerolls in the next shop start at $0
+	D6Tag TagType = "D6 Tag"
+	// CouponTag makes the initial cards in the next shop free
+	CouponTag TagType = "Coupon Tag"
+	// EconomyTag doubles your money, up to EconomyTagMax
+	EconomyTag TagType = "Economy Tag"
+	// InvestmentTag pays InvestmentTagPayout after the next Boss Blind
+	InvestmentTag TagType = "Investment Tag"
+	// BuffoonTag adds a free Mega Buffoon Pack to the next shop
+	BuffoonTag TagType = "Buffoon Tag"
+)
+
+// Tag reward amounts
+const (
+	EconomyTagMax       = 40
+	InvestmentTagPayout = 25
+)
+
+// allTags lists the tags that can be offered for skipping a blind
+var allTags = []TagType{D6Tag, CouponTag, EconomyTag, InvestmentTag, BuffoonTag}
+
+// Tag is a reward earned by skipping a Small or Big Blind
+type Tag struct {
+	Type TagType
+}
+
+// Name returns the tag's display name
+func (t Tag) Name() string {
+	return string(t.Type)
+}
+
+// Description returns what the tag does
+func (t Tag) Description() string {
+	switch t.Type {
+	case D6Tag:
+		return "Rerolls in the next shop start at $0"
+	case CouponTag:
+		return "Initial cards and packs in the next shop are free"
+	case EconomyTag:
+		return fmt.Sprintf("Doubles your money (max +$%d)", EconomyTagMax)
+	case InvestmentTag:
+		return fmt.Sprintf("Gain $%d after defeating the next Boss Blind", InvestmentTagPayout)
+	case BuffoonTag:
+		return "The next shop has a free Mega Buffoon Pack"
+	default:
+		return ""
+	}
+}
+
+// ParseTag returns the tag with the given name
+func ParseTag(name string) (Tag, error) {
+	for _, t := range allTags {
+		if string(t) == name {
+			return Tag{Type: t}, nil
+		}
+	}
+	return Tag{}, fmt.Errorf("unknown tag %q", name)
+}
+
+// TagForBlind returns the tag offered for skipping a blind. It is seeded by
+// the run seed so the offer doesn't change when a run is reloaded.
+func TagForBlind(ante int, blind BlindType) Tag {
+	r := rand.New(rand.NewSource(GetSeed() + int64(ante)*3 + int64(blind)))
+	return Tag{Type: allTags[r.Intn(len(allTags))]}
+}
+
+// addTag grants a tag, applying tags that pay out immediately
+func (g *Game) addTag(tag Tag) {
+	if tag.Type == EconomyTag {
+		bonus := g.money
+		if bonus > EconomyTagMax {
+			bonus = EconomyTagMax
+		}
+		if bonus < 0 {
+			bonus = 0
+		}
+		g.money += bonus
+		g.eventEmitter.EmitSuccess(fmt.Sprintf("%s: +$%d", tag.Name(), bonus))
+		return
+	}
+	g.tags = append(g.tags, tag)
+}
+
+// useTags removes every held tag of the given type and returns how many
+// there were
+func (g *Game) useTags(tagType TagType) int {
+	used := 0
+	kept := g.tags[:0]
+	for _, t := range g.tags {
+		if t.Type == tagType {
+			used++
+		} else {
+			kept = append(kept, t)
+		}
+	}
+	g.tags = kept
+	return used
+}
+
+// applyShopTags uses held tags that improve a newly stocked shop
+func (g *Game) applyShopTags(shop *Shop) {
+	if g.useTags(D6Tag) > 0 {
+		shop.RerollCost = 0
+		g.eventEmitter.EmitSuccess("D6 Tag: rerolls start at $0")
+	}
+	if g.useTags(CouponTag) > 0 {
+		for i := range shop.Items {
+			shop.Items[i].Joker.PurchasePrice = 0
+			shop.Items[i].Consumable.Price = 0
+		}
+		for _, item := range shop.Packs {
+			item.Pack.Price = 0
+		}
+		g.eventEmitter.EmitSuccess("Coupon Tag: initial cards and packs are free")
+	}
+	for i := g.useTags(BuffoonTag); i > 0; i-- {
+		pack, ok := GetPack(BuffoonPack, MegaPack)
+		if !ok {
+			break
+		}
+		pack.Price = 0
+		shop.Packs = append([]ShopItem{{Pack: &pack}}, shop.Packs...)
+		g.eventEmitter.EmitSuccess("Buffoon Tag: a free Mega Buffoon Pack is on sale")
+	}
+}
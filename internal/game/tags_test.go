@@ -0,0 +1,176 @@
+package game
+
+import "testing"
+
+// blindTestHandler answers blind selection with scripted actions, then plays.
+type blindTestHandler struct {
+	testEventHandler
+	blindActions []PlayerAction
+}
+
+func (h *blindTestHandler) GetBlindAction() (PlayerAction, []string, bool) {
+	if len(h.blindActions) == 0 {
+		return PlayerActionSelectBlind, nil, false
+	}
+	a := h.blindActions[0]
+	h.blindActions = h.blindActions[1:]
+	return a, nil, false
+}
+
+// newTagTestGame returns a game at the start of ante 1 using the given handler.
+func newTagTestGame(handler EventHandler) *Game {
+	g := &Game{
+		deck:         NewDeck(),
+		currentAnte:  1,
+		currentBlind: SmallBlind,
+		money:        10,
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+	return g
+}
+
+// TestSkipBlindGrantsTag verifies skipping advances the blind and grants its tag.
+func TestSkipBlindGrantsTag(t *testing.T) {
+	SetSeed(7)
+	handler := &blindTestHandler{blindActions: []PlayerAction{PlayerActionSkipBlind, PlayerActionSkipBlind, PlayerActionSkipBlind}}
+	g := newTagTestGame(handler)
+	smallTag := TagForBlind(1, SmallBlind)
+	bigTag := TagForBlind(1, BigBlind)
+
+	if !g.selectBlind() {
+		t.Fatalf("expected selectBlind to report a skip")
+	}
+	if g.currentBlind != BossBlind {
+		t.Fatalf("expected to reach the Boss Blind, got %s", g.currentBlind)
+	}
+
+	var skipped []Tag
+	invalid := 0
+	for _, e := range handler.events {
+		switch ev := e.(type) {
+		case BlindSkippedEvent:
+			skipped = append(skipped, ev.Tag)
+		case InvalidActionEvent:
+			invalid++
+		}
+	}
+	if len(skipped) != 2 || skipped[0] != smallTag || skipped[1] != bigTag {
+		t.Fatalf("expected the Small and Big Blind tags, got %v", skipped)
+	}
+	if invalid != 1 {
+		t.Fatalf("expected skipping the Boss Blind to be rejected once, got %d", invalid)
+	}
+
+	held := 0
+	for _, tag := range skipped {
+		if tag.Type != EconomyTag {
+			held++
+		}
+	}
+	if len(g.tags) != held {
+		t.Fatalf("expected %d held tags, got %v", held, g.tags)
+	}
+}
+
+// TestBlindSelectionPreview verifies the preview lists all three blinds of the ante.
+func TestBlindSelectionPreview(t *testing.T) {
+	setDefaultBosses()
+	g := newTagTestGame(&testEventHandler{})
+	event := g.blindSelectionEvent()
+
+	if len(event.Blinds) != 3 {
+		t.Fatalf("expected 3 blinds, got %d", len(event.Blinds))
+	}
+	boss := event.Blinds[2]
+	if boss.Boss == nil || boss.Tag != nil {
+		t.Fatalf("expected the Boss Blind to show its boss and no tag")
+	}
+	if want := boss.Boss.ScaleTarget(g.blindTarget(1, BossBlind)); boss.Target != want {
+		t.Fatalf("boss target = %s, want %s", boss.Target, want)
+	}
+	for _, b := range event.Blinds[:2] {
+		if b.Tag == nil || b.Boss != nil {
+			t.Fatalf("expected %s to offer a tag", b.Blind)
+		}
+	}
+}
+
+// TestShopComesBeforeBlindSelection verifies defeating a blind opens the shop
+// and then waits for the next blind to be chosen.
+func TestShopComesBeforeBlindSelection(t *testing.T) {
+	handler := &testEventHandler{}
+	g := newTagTestGame(handler)
+	g.phase = PhasePlaying
+
+	g.handleBlindCompletion()
+	if g.phase != PhaseBlindSelect {
+		t.Fatalf("expected the blind select phase after the shop, got %s", g.phase)
+	}
+	for _, e := range handler.events {
+		if _, ok := e.(NewBlindStartedEvent); ok {
+			t.Fatalf("expected the next blind not to start before it is chosen")
+		}
+	}
+
+	g.startBlind()
+	if g.phase != PhasePlaying || g.currentBlind != BigBlind {
+		t.Fatalf("expected to be playing the Big Blind, got %s in %s", g.currentBlind, g.phase)
+	}
+	if want := g.blindTarget(1, BigBlind); g.currentTarget != want {
+		t.Fatalf("target = %s, want %s", g.currentTarget, want)
+	}
+}
+
+// TestTagEffects verifies each tag pays out at the right time.
+func TestTagEffects(t *testing.T) {
+	g := newTagTestGame(&testEventHandler{})
+
+	g.money = 30
+	g.addTag(Tag{Type: EconomyTag})
+	if g.money != 60 || len(g.tags) != 0 {
+		t.Fatalf("expected Economy Tag to double money immediately, money=%d tags=%v", g.money, g.tags)
+	}
+	g.addTag(Tag{Type: EconomyTag})
+	if g.money != 60+EconomyTagMax {
+		t.Fatalf("expected Economy Tag to be capped at $%d, money=%d", EconomyTagMax, g.money)
+	}
+
+	g.tags = []Tag{{Type: D6Tag}, {Type: CouponTag}, {Type: InvestmentTag}}
+	shop := &Shop{game: g, RerollCost: BaseRerollCost, Items: []ShopItem{{Joker: OwnedJoker{Joker: Joker{Name: "A", Price: 5}, PurchasePrice: 5}}, {Consumable: Consumable{Name: "B", Price: 3}}}}
+	g.applyShopTags(shop)
+	if shop.RerollCost != 0 || shop.Items[0].Price() != 0 || shop.Items[1].Price() != 0 {
+		t.Fatalf("expected free rerolls and cards, reroll=%d items=%v", shop.RerollCost, shop.Items)
+	}
+	if len(g.tags) != 1 || g.tags[0].Type != InvestmentTag {
+		t.Fatalf("expected only the Investment Tag to remain, got %v", g.tags)
+	}
+}
+
+// TestInvestmentTagPaysAfterBoss verifies the Investment Tag pays out after the Boss Blind.
+func TestInvestmentTagPaysAfterBoss(t *testing.T) {
+	handler := &testEventHandler{}
+	g := newTagTestGame(handler)
+	g.tags = []Tag{{Type: InvestmentTag}}
+
+	g.currentBlind = BigBlind
+	g.handleBlindCompletion()
+	if len(g.tags) != 1 {
+		t.Fatalf("expected the Investment Tag to wait for the Boss Blind")
+	}
+
+	g.currentBlind = BossBlind
+	g.handleBlindCompletion()
+	if len(g.tags) != 0 {
+		t.Fatalf("expected the Investment Tag to be used")
+	}
+	var reward int
+	for _, e := range handler.events {
+		if d, ok := e.(BlindDefeatedEvent); ok {
+			reward = d.TagReward
+		}
+	}
+	if reward != InvestmentTagPayout {
+		t.Fatalf("expected a $%d tag reward, got %d", InvestmentTagPayout, reward)
+	}
+}
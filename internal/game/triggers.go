@@ -0,0 +1,523 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// JokerTrigger is a point in the game where joker effects and counters fire
+type JokerTrigger string
+
+const (
+	// TriggerCardScored fires for each played card, left to right, before
+	// the hand's joker bonuses
+	TriggerCardScored JokerTrigger = "card_scored"
+	// TriggerHeldInHand fires for each card left in hand when a hand is played
+	TriggerHeldInHand JokerTrigger = "held_in_hand"
+	// TriggerHandPlayed fires once per hand, after the card triggers
+	TriggerHandPlayed JokerTrigger = "hand_played"
+	// TriggerHandScored fires once per hand, after it is scored
+	TriggerHandScored JokerTrigger = "hand_scored"
+	// TriggerDiscard fires once per discard
+	TriggerDiscard JokerTrigger = "discard"
+	// TriggerBlindEnd fires when a blind is defeated
+	TriggerBlindEnd JokerTrigger = "blind_end"
+	// TriggerBlindSelect fires when a blind is chosen and starts
+	TriggerBlindSelect JokerTrigger = "blind_select"
+	// TriggerPlanetUsed fires when a Planet card is used
+	TriggerPlanetUsed JokerTrigger = "planet_used"
+)
+
+// jokerTriggers lists every trigger in the order they fire during a hand
+var jokerTriggers = []string{
+	string(TriggerBlindSelect), string(TriggerCardScored), string(TriggerHeldInHand),
+	string(TriggerHandPlayed), string(TriggerHandScored), string(TriggerDiscard),
+	string(TriggerBlindEnd), string(TriggerPlanetUsed),
+}
+
+// scoringTriggers are the phases a hand's joker bonuses are worked out in
+var scoringTriggers = []JokerTrigger{TriggerCardScored, TriggerHeldInHand, TriggerHandPlayed}
+
+// gameTriggers are the triggers that fire outside of scoring a hand
+var gameTriggers = []JokerTrigger{TriggerBlindSelect, TriggerHandPlayed, TriggerDiscard, TriggerBlindEnd}
+
+// effectTriggers lists the triggers each effect can fire on. Effects that
+// aren't listed, like AddHandSize, the copy effects and the card modifiers,
+// always apply and take no trigger.
+var effectTriggers = map[JokerEffect][]JokerTrigger{
+	AddChips:         scoringTriggers,
+	AddMult:          scoringTriggers,
+	MultiplyMult:     scoringTriggers,
+	MultiplyChips:    scoringTriggers,
+	ReplayCard:       {TriggerCardScored},
+	RetriggerCard:    {TriggerCardScored, TriggerHeldInHand},
+	AddMoney:         {TriggerBlindEnd, TriggerBlindSelect, TriggerCardScored, TriggerHeldInHand, TriggerHandPlayed, TriggerDiscard},
+	AddMoneyPerCard:  {TriggerHandPlayed, TriggerDiscard},
+	GainSellValue:    {TriggerBlindEnd, TriggerBlindSelect, TriggerCardScored, TriggerHandPlayed, TriggerDiscard},
+	GiftSellValue:    {TriggerBlindEnd, TriggerBlindSelect},
+	CreateConsumable: gameTriggers,
+	DestroyCard:      {TriggerCardScored, TriggerDiscard},
+}
+
+// defaultTrigger returns when an effect fires if jokers.yaml doesn't say:
+// scoring effects with a card rule fire per scored card, other scoring
+// effects once per hand, and money and sell value at the end of the blind
+func defaultTrigger(effect JokerEffect, hasCardRule bool) JokerTrigger {
+	switch effect {
+	case AddChips, AddMult, MultiplyMult, MultiplyChips:
+		if hasCardRule {
+			return TriggerCardScored
+		}
+		return TriggerHandPlayed
+	case ReplayCard, RetriggerCard, DestroyCard:
+		return TriggerCardScored
+	case AddMoneyPerCard:
+		return TriggerHandPlayed
+	case AddMoney, GainSellValue, GiftSellValue:
+		return TriggerBlindEnd
+	case CreateConsumable:
+		return TriggerBlindSelect
+	default:
+		return ""
+	}
+}
+
+// trigger returns when the effect fires, falling back to its default
+func (e JokerEffectConfig) trigger() JokerTrigger {
+	if e.Trigger != "" {
+		return e.Trigger
+	}
+	return defaultTrigger(e.Effect, e.rules().hasCardRule())
+}
+
+// validateTrigger checks that an effect can fire on its trigger
+func validateTrigger(name string, eff JokerEffectConfig) error {
+	allowed, ok := effectTriggers[eff.Effect]
+	if !ok {
+		if eff.Trigger != "" {
+			return fmt.Errorf("joker %s: %s always applies and can't have a trigger", name, eff.Effect)
+		}
+		return nil
+	}
+	if eff.Trigger == "" {
+		return nil
+	}
+	var names []string
+	for _, t := range allowed {
+		if t == eff.Trigger {
+			return nil
+		}
+		names = append(names, string(t))
+	}
+	return fmt.Errorf("joker %s: %s can't trigger on %q (expected one of %s)", name, eff.Effect, eff.Trigger, strings.Join(names, ", "))
+}
+
+// cardTriggers are the triggers that have cards for card rules to check
+var cardTriggers = []JokerTrigger{TriggerCardScored, TriggerHeldInHand, TriggerHandPlayed, TriggerHandScored, TriggerDiscard}
+
+// hasCards reports whether the trigger has cards for card rules to check
+func (t JokerTrigger) hasCards() bool {
+	for _, c := range cardTriggers {
+		if t == c {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRules are the rules limiting when an effect or counter update applies
+type matchRules struct {
+	hand          HandMatchingRule
+	card          CardMatchingRule
+	condition     *Condition
+	cardCondition *CardCondition
+}
+
+// rules returns the effect's matching rules
+func (e JokerEffectConfig) rules() matchRules {
+	return matchRules{e.HandMatchingRule, e.CardMatchingRule, e.Condition, e.CardCondition}
+}
+
+// hasCardRule reports whether the rules pick out particular cards
+func (r matchRules) hasCardRule() bool {
+	return (r.card != "" && r.card != CardNone) || r.cardCondition != nil
+}
+
+// cardMatches reports whether a card satisfies the card rules; with no rule
+// every card that isn't debuffed matches
+func (r matchRules) cardMatches(card Card, m CardModifiers) bool {
+	if card.Debuffed {
+		return false
+	}
+	if r.card != "" && r.card != CardNone && !cardMatchesRule(card, r.card, m) {
+		return false
+	}
+	return r.cardCondition == nil || r.cardCondition.matches(card, m)
+}
+
+// holds reports whether the hand rule and condition hold
+func (r matchRules) holds(state ConditionState) bool {
+	if state.HandType != "" && r.hand != "" && !handMatchesRule(state.HandType, r.hand) {
+		return false
+	}
+	return r.condition == nil || r.condition.holds(state)
+}
+
+// triggerMatches returns how many times something firing on trigger applies.
+// Nothing applies unless the hand rule and condition hold. Card triggers
+// then apply once per matching card, as do discards with a card rule. Hand
+// triggers apply once when, with a card rule, the hand holds a matching
+// card. Other triggers apply once.
+func triggerMatches(trigger JokerTrigger, rules matchRules, state ConditionState, cards []Card) int {
+	if !rules.holds(state) {
+		return 0
+	}
+	hasCardRule := rules.hasCardRule()
+	matches := 0
+	for _, c := range cards {
+		if rules.cardMatches(c, state.Cards) {
+			matches++
+		}
+	}
+	switch trigger {
+	case TriggerCardScored, TriggerHeldInHand:
+		return matches
+	case TriggerDiscard:
+		if hasCardRule {
+			return matches
+		}
+		return 1
+	case TriggerHandPlayed, TriggerHandScored:
+		if hasCardRule && matches == 0 {
+			return 0
+		}
+		return 1
+	default:
+		return 1
+	}
+}
+
+// ScoringStep is one joker effect applied while scoring a hand or firing
+// a trigger, in the order the engine applied it
+type ScoringStep struct {
+	Joker string
+	// JokerIndex is the position of the joker that fired, from 0
+	JokerIndex int
+	Trigger    JokerTrigger
+	Effect     JokerEffect
+	// Card is the card that triggered the step, if any
+	Card   *Card
+	Amount int
+	// Consumable is what a CreateConsumable step makes
+	Consumable string
+}
+
+// firedEffect is an effect a joker fires. Copy jokers fire the effects of
+// the joker they copy, at that joker's magnitude.
+type firedEffect struct {
+	index  int
+	name   string
+	source OwnedJoker
+	eff    JokerEffectConfig
+}
+
+// firedEffects lists the effects each active joker fires, left to right,
+// with copy effects replaced by the effects they copy
+func firedEffects(jokers []OwnedJoker) []firedEffect {
+	var fired []firedEffect
+	for i, joker := range jokers {
+		if !joker.Active() {
+			continue
+		}
+		name, _ := joker.Face()
+		for _, eff := range joker.Effects {
+			if isCopyEffect(eff.Effect) {
+				for _, copied := range copiedEffects(jokers, i, eff.Effect, len(jokers)) {
+					copied.index, copied.name = i, name
+					fired = append(fired, copied)
+				}
+				continue
+			}
+			fired = append(fired, firedEffect{index: i, name: name, source: joker, eff: eff})
+		}
+	}
+	return fired
+}
+
+// isCopyEffect reports whether the effect copies another joker
+func isCopyEffect(effect JokerEffect) bool {
+	return effect == CopyJokerRight || effect == CopyJokerLeftmost
+}
+
+// copyable reports whether a copy joker can copy the effect; passive, card
+// modifier and sell value effects belong to the joker itself
+func copyable(effect JokerEffect) bool {
+	switch effect {
+	case AddHandSize, AddDiscards, CountAllAsFace, MergeSuits, GainSellValue, GiftSellValue:
+		return false
+	default:
+		return true
+	}
+}
+
+// copiedEffects returns the effects the joker at i copies: the joker to its
+// right for CopyJokerRight, the leftmost joker for CopyJokerLeftmost. depth
+// stops copy jokers copying each other forever.
+func copiedEffects(jokers []OwnedJoker, i int, effect JokerEffect, depth int) []firedEffect {
+	target := i + 1
+	if effect == CopyJokerLeftmost {
+		target = 0
+	}
+	if depth <= 0 || target == i || target >= len(jokers) || !jokers[target].Active() {
+		return nil
+	}
+	var copied []firedEffect
+	for _, eff := range jokers[target].Effects {
+		switch {
+		case isCopyEffect(eff.Effect):
+			copied = append(copied, copiedEffects(jokers, target, eff.Effect, depth-1)...)
+		case copyable(eff.Effect):
+			copied = append(copied, firedEffect{source: jokers[target], eff: eff})
+		}
+	}
+	return copied
+}
+
+// step builds the trace step for a fired effect
+func (f firedEffect) step(trigger JokerTrigger, card *Card) ScoringStep {
+	return ScoringStep{
+		Joker: f.name, JokerIndex: f.index, Trigger: trigger, Effect: f.eff.Effect,
+		Card: card, Amount: f.source.effectMagnitude(f.eff), Consumable: f.eff.Consumable,
+	}
+}
+
+// JokerScoringSteps works out the joker effects for a played hand in order:
+// each played card left to right, then each card held in hand, then the
+// hand itself, with jokers applied left to right within each. Retriggered
+// cards fire again straight after themselves.
+func JokerScoringSteps(jokers []OwnedJoker, state ConditionState, played, held []Card) []ScoringStep {
+	return scoringSteps(jokers, state, played, held, true)
+}
+
+// scoringSteps works out a hand's joker effects; without retriggers the
+// played cards must already include any replays
+func scoringSteps(jokers []OwnedJoker, state ConditionState, played, held []Card, retrigger bool) []ScoringStep {
+	state.Cards = cardModifiers(jokers)
+	fired := firedEffects(jokers)
+	var steps []ScoringStep
+	for _, trigger := range scoringTriggers {
+		switch trigger {
+		case TriggerCardScored, TriggerHeldInHand:
+			cards := played
+			if trigger == TriggerHeldInHand {
+				cards = held
+			}
+			for i := range cards {
+				card := cards[i]
+				steps = append(steps, triggerSteps(fired, trigger, state, []Card{card}, &card)...)
+				if !retrigger {
+					continue
+				}
+				for _, r := range retriggerSteps(fired, trigger, state, card) {
+					steps = append(steps, r)
+					for n := 0; n < r.Amount; n++ {
+						steps = append(steps, retriggeredSteps(fired, trigger, state, card)...)
+					}
+				}
+			}
+		default:
+			steps = append(steps, triggerSteps(fired, trigger, state, played, nil)...)
+		}
+	}
+	return steps
+}
+
+// retriggerSteps returns a step for each joker that retriggers the card,
+// with Amount set to the number of extra times it fires
+func retriggerSteps(fired []firedEffect, trigger JokerTrigger, state ConditionState, card Card) []ScoringStep {
+	var steps []ScoringStep
+	for _, f := range fired {
+		if f.eff.trigger() != trigger || (f.eff.Effect == ReplayCard && !f.eff.rules().hasCardRule()) {
+			continue
+		}
+		if triggerMatches(trigger, f.eff.rules(), state, []Card{card}) == 0 {
+			continue
+		}
+		c := card
+		switch f.eff.Effect {
+		case ReplayCard:
+			step := f.step(trigger, &c)
+			step.Amount = 1
+			steps = append(steps, step)
+		case RetriggerCard:
+			if step := f.step(trigger, &c); step.Amount > 0 {
+				steps = append(steps, step)
+			}
+		}
+	}
+	return steps
+}
+
+// retriggeredSteps are the steps a retriggered card fires again. A card can
+// only be destroyed once, so DestroyCard isn't repeated.
+func retriggeredSteps(fired []firedEffect, trigger JokerTrigger, state ConditionState, card Card) []ScoringStep {
+	var steps []ScoringStep
+	for _, step := range triggerSteps(fired, trigger, state, []Card{card}, &card) {
+		if step.Effect != DestroyCard {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// triggerSteps applies the fired effects for one trigger. card is set when
+// the trigger fires for a single card. Per-card effects on a trigger with
+// several cards fire once for each matching card.
+func triggerSteps(fired []firedEffect, trigger JokerTrigger, state ConditionState, cards []Card, card *Card) []ScoringStep {
+	var steps []ScoringStep
+	for _, f := range fired {
+		if f.eff.trigger() != trigger {
+			continue
+		}
+		switch f.eff.Effect {
+		case ReplayCard, RetriggerCard:
+			continue
+		case AddMoneyPerCard, DestroyCard:
+			if card == nil {
+				if !f.eff.rules().holds(state) {
+					continue
+				}
+				for i := range cards {
+					if c := cards[i]; f.eff.rules().cardMatches(c, state.Cards) {
+						steps = append(steps, f.step(trigger, &c))
+					}
+				}
+				continue
+			}
+		}
+		for n := triggerMatches(trigger, f.eff.rules(), state, cards); n > 0; n-- {
+			steps = append(steps, f.step(trigger, card))
+		}
+	}
+	return steps
+}
+
+// jokerTriggerSteps works out the joker effects for a trigger outside of
+// scoring a hand
+func jokerTriggerSteps(jokers []OwnedJoker, trigger JokerTrigger, state ConditionState, cards []Card) []ScoringStep {
+	state.Cards = cardModifiers(jokers)
+	return triggerSteps(firedEffects(jokers), trigger, state, cards, nil)
+}
+
+// sumScoringSteps totals scoring steps into chip and mult bonuses and a
+// mult factor
+func sumScoringSteps(steps []ScoringStep) (int, int, Score) {
+	chips, mult, factor := 0, 0, Score(1)
+	for _, step := range steps {
+		switch step.Effect {
+		case AddChips:
+			chips += step.Amount
+		case AddMult:
+			mult += step.Amount
+		case MultiplyMult:
+			factor = factor.Mul(Score(step.Amount))
+		}
+	}
+	return chips, mult, factor
+}
+
+// scoreHand applies scoring steps to a hand's chips and mult and returns the
+// final chips and mult. Steps run in order against a running total, so +Mult
+// before ×Mult is worth more than after; with legacy scoring every +Chips
+// and +Mult is added before any ×Chips or ×Mult.
+func scoreHand(chips, mult int, steps []ScoringStep, legacy bool) (Score, Score) {
+	if legacy {
+		jokerChips, jokerMult, factor := sumScoringSteps(steps)
+		total := Score(chips + jokerChips)
+		for _, step := range steps {
+			if step.Effect == MultiplyChips {
+				total = total.Mul(Score(step.Amount))
+			}
+		}
+		return total, Score(mult + jokerMult).Mul(factor)
+	}
+	runningChips, runningMult := Score(chips), Score(mult)
+	for _, step := range steps {
+		switch step.Effect {
+		case AddChips:
+			runningChips = runningChips.Add(Score(step.Amount))
+		case MultiplyChips:
+			runningChips = runningChips.Mul(Score(step.Amount))
+		case AddMult:
+			runningMult = runningMult.Add(Score(step.Amount))
+		case MultiplyMult:
+			runningMult = runningMult.Mul(Score(step.Amount))
+		}
+	}
+	return runningChips, runningMult
+}
+
+// jokerMoneyLines itemizes the money jokers pay when a trigger fires
+func jokerMoneyLines(jokers []OwnedJoker, trigger JokerTrigger, state ConditionState, cards []Card) []RewardLine {
+	return moneyLines(jokerTriggerSteps(jokers, trigger, state, cards))
+}
+
+// moneyLines totals the money in a set of steps for each joker, in the order
+// the jokers first paid
+func moneyLines(steps []ScoringStep) []RewardLine {
+	var lines []RewardLine
+	seen := make(map[int]int)
+	for _, step := range steps {
+		if step.Effect != AddMoney && step.Effect != AddMoneyPerCard {
+			continue
+		}
+		i, ok := seen[step.JokerIndex]
+		if !ok {
+			i = len(lines)
+			seen[step.JokerIndex] = i
+			lines = append(lines, RewardLine{Kind: RewardJoker, Label: step.Joker})
+		}
+		lines[i].Amount += step.Amount
+	}
+	var paid []RewardLine
+	for _, line := range lines {
+		if line.Amount != 0 {
+			paid = append(paid, line)
+		}
+	}
+	return paid
+}
+
+// fireJokers applies the joker effects for a trigger outside of scoring a
+// hand. Money at the end of a blind is paid with the blind's rewards instead.
+func (g *Game) fireJokers(trigger JokerTrigger, state ConditionState, cards []Card) {
+	steps := jokerTriggerSteps(g.jokers, trigger, state, cards)
+	g.applyJokerSteps(steps, trigger != TriggerBlindEnd)
+}
+
+// applyJokerSteps carries out the effects in a set of steps that reach
+// beyond the score: money, sell value, new consumables and destroyed cards
+func (g *Game) applyJokerSteps(steps []ScoringStep, payMoney bool) {
+	if payMoney {
+		for _, line := range moneyLines(steps) {
+			g.money += line.Amount
+			g.eventEmitter.EmitInfo(fmt.Sprintf("%s: +$%d", line.Label, line.Amount))
+		}
+	}
+	for _, step := range steps {
+		switch step.Effect {
+		case GainSellValue:
+			g.jokers[step.JokerIndex].SellBonus += step.Amount
+		case GiftSellValue:
+			for i := range g.jokers {
+				g.jokers[i].SellBonus += step.Amount
+			}
+		case CreateConsumable:
+			g.jokerCreateConsumables(step)
+		case DestroyCard:
+			if step.Card != nil && g.destroyCard(*step.Card) {
+				g.eventEmitter.EmitInfo(fmt.Sprintf("%s destroyed %s", step.Joker, step.Card))
+			}
+		}
+	}
+}
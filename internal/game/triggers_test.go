@@ -0,0 +1,213 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDefaultTriggers verifies effects without a trigger keep firing when
+// they always have.
+func TestDefaultTriggers(t *testing.T) {
+	cases := []struct {
+		effect JokerEffect
+		rule   CardMatchingRule
+		want   JokerTrigger
+	}{
+		{AddMult, "", TriggerHandPlayed},
+		{AddChips, CardNone, TriggerHandPlayed},
+		{AddChips, CardIsAce, TriggerCardScored},
+		{ReplayCard, CardIsFace, TriggerCardScored},
+		{AddMoney, "", TriggerBlindEnd},
+		{GainSellValue, "", TriggerBlindEnd},
+		{AddHandSize, "", ""},
+	}
+	for _, c := range cases {
+		if got := (JokerEffectConfig{Effect: c.effect, CardMatchingRule: c.rule}).trigger(); got != c.want {
+			t.Errorf("%s with %q: trigger = %q, want %q", c.effect, c.rule, got, c.want)
+		}
+	}
+}
+
+// TestValidateJokerTriggers verifies triggers an effect can't use are
+// reported with the joker name.
+func TestValidateJokerTriggers(t *testing.T) {
+	cases := []struct {
+		config JokerConfig
+		want   string
+	}{
+		{JokerConfig{Name: "Shoot the Moon", Effect: AddMult, Trigger: "on_sell"}, `can't trigger on "on_sell"`},
+		{JokerConfig{Name: "Gift Card", Effect: GiftSellValue, Trigger: TriggerDiscard}, `can't trigger on "discard"`},
+		{JokerConfig{Name: "Juggler", Effect: AddHandSize, Trigger: TriggerBlindSelect}, "can't have a trigger"},
+	}
+	for _, c := range cases {
+		err := validateJokerConfig(c.config)
+		if err == nil || !strings.Contains(err.Error(), c.config.Name) || !strings.Contains(err.Error(), c.want) {
+			t.Errorf("validateJokerConfig(%s) = %v, want an error mentioning %q", c.config.Name, err, c.want)
+		}
+	}
+	ok := JokerConfig{Name: "Mail-In Rebate", Effect: AddMoney, CardMatchingRule: CardIsFace, Trigger: TriggerDiscard}
+	if err := validateJokerConfig(ok); err != nil {
+		t.Errorf("expected %s to be valid, got %v", ok.Name, err)
+	}
+}
+
+// TestJokerScoringStepOrder verifies card triggers fire before held cards,
+// held cards before the hand, and jokers left to right within each.
+func TestJokerScoringStepOrder(t *testing.T) {
+	jokers := []OwnedJoker{
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Times", Effect: MultiplyMult, EffectMagnitude: 2})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Held", Effect: AddMult, EffectMagnitude: 5, CardMatchingRule: CardIsFace, Trigger: TriggerHeldInHand})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Aces", Effect: AddChips, EffectMagnitude: 10, CardMatchingRule: CardIsAce})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Plus", Effect: AddMult, EffectMagnitude: 4})),
+	}
+	played := []Card{{Rank: Ace, Suit: Spades}, {Rank: Ace, Suit: Hearts}}
+	held := []Card{{Rank: King, Suit: Clubs}, {Rank: Two, Suit: Clubs}, {Rank: Queen, Suit: Clubs}}
+
+	steps := JokerScoringSteps(jokers, ConditionState{HandType: "Pair"}, played, held)
+	var got []string
+	for _, step := range steps {
+		got = append(got, step.Joker+":"+string(step.Trigger))
+	}
+	want := []string{
+		"Aces:card_scored", "Aces:card_scored",
+		"Held:held_in_hand", "Held:held_in_hand",
+		"Times:hand_played", "Plus:hand_played",
+	}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("steps = %v, want %v", got, want)
+	}
+	if steps[2].Card == nil || steps[2].Card.Rank != King || steps[3].Card.Rank != Queen {
+		t.Errorf("expected held steps to point at the King then the Queen, got %+v and %+v", steps[2].Card, steps[3].Card)
+	}
+
+	chips, mult, factor := sumScoringSteps(steps)
+	if chips != 20 || mult != 14 || factor != 2 {
+		t.Errorf("totals = %d chips, +%d mult, x%d, want 20, +14, x2", chips, mult, factor)
+	}
+}
+
+// TestHeldInHandScoring verifies a played hand counts the cards left in hand.
+func TestHeldInHandScoring(t *testing.T) {
+	LoadConfig()
+	handler := &testEventHandler{}
+	g := &Game{
+		deck:         NewDeck(),
+		deckIndex:    InitialCards,
+		playerCards:  []Card{{Rank: Two, Suit: Hearts}, {Rank: King, Suit: Clubs}, {Rank: Queen, Suit: Spades}},
+		handLevels:   map[string]int{},
+		jokers:       []OwnedJoker{newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Shoot the Moon", Effect: AddMult, EffectMagnitude: 5, CardMatchingRule: CardIsFace, Trigger: TriggerHeldInHand}))},
+		eventEmitter: NewEventEmitter(),
+	}
+	g.eventEmitter.SetEventHandler(handler)
+
+	g.handlePlayAction([]string{"1"})
+	var played HandPlayedEvent
+	for _, e := range handler.events {
+		if hp, ok := e.(HandPlayedEvent); ok {
+			played = hp
+		}
+	}
+	if played.JokerMult != 10 {
+		t.Fatalf("expected +5 Mult for each of two face cards held, got %d", played.JokerMult)
+	}
+}
+
+// TestDiscardAndBlindSelectTriggers verifies money and sell value effects
+// fire on discards and when a blind starts.
+func TestDiscardAndBlindSelectTriggers(t *testing.T) {
+	g := createTestGame([]string{})
+	g.playerCards = []Card{{Rank: King, Suit: Hearts}, {Rank: Jack, Suit: Clubs}, {Rank: Two, Suit: Spades}}
+	g.jokers = []OwnedJoker{
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Mail-In Rebate", Effect: AddMoney, EffectMagnitude: 2, CardMatchingRule: CardIsFace, Trigger: TriggerDiscard})),
+		newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Early Egg", Value: 4, Effect: GainSellValue, EffectMagnitude: 3, Trigger: TriggerBlindSelect})),
+	}
+	money := g.money
+
+	g.handleDiscardAction([]string{"1", "2", "3"})
+	if g.money != money+4 {
+		t.Fatalf("expected $2 for each of two face cards discarded, money went from %d to %d", money, g.money)
+	}
+
+	g.fireJokers(TriggerBlindEnd, ConditionState{}, nil)
+	if g.jokers[1].SellBonus != 0 {
+		t.Fatalf("expected a blind_select joker to ignore the end of the blind, bonus=%d", g.jokers[1].SellBonus)
+	}
+	g.deck = NewDeck()
+	g.startBlind()
+	if g.jokers[1].SellBonus != 3 {
+		t.Errorf("expected starting a blind to add $3 of sell value, bonus=%d", g.jokers[1].SellBonus)
+	}
+}
+
+// TestScoreHandOrdering verifies +Mult before ×Mult beats ×Mult before
+// +Mult, and that legacy scoring ignores the order.
+func TestScoreHandOrdering(t *testing.T) {
+	plus := ScoringStep{Joker: "Plus", Effect: AddMult, Amount: 8}
+	times := ScoringStep{Joker: "Times", Effect: MultiplyMult, Amount: 2}
+	chipsStep := ScoringStep{Joker: "Chips", Effect: AddChips, Amount: 30}
+	cases := []struct {
+		name   string
+		steps  []ScoringStep
+		legacy bool
+		chips  Score
+		mult   Score
+	}{
+		{"plus then times", []ScoringStep{plus, times}, false, 10, 24},
+		{"times then plus", []ScoringStep{times, plus}, false, 10, 16},
+		{"chips anywhere", []ScoringStep{times, chipsStep, plus}, false, 40, 16},
+		{"times twice", []ScoringStep{plus, times, plus, times}, false, 10, 64},
+		{"legacy plus then times", []ScoringStep{plus, times}, true, 10, 24},
+		{"legacy times then plus", []ScoringStep{times, plus}, true, 10, 24},
+		{"no jokers", nil, false, 10, 4},
+	}
+	for _, c := range cases {
+		chips, mult := scoreHand(10, 4, c.steps, c.legacy)
+		if chips != c.chips || mult != c.mult {
+			t.Errorf("%s: got %d chips × %s mult, want %d × %s", c.name, chips, mult, c.chips, c.mult)
+		}
+	}
+}
+
+// TestMoveJokerChangesScore verifies reordering jokers changes the score of
+// the same hand.
+func TestMoveJokerChangesScore(t *testing.T) {
+	LoadConfig()
+	play := func(jokers []OwnedJoker) HandPlayedEvent {
+		handler := &testEventHandler{}
+		g := &Game{
+			deck:         NewDeck(),
+			deckIndex:    InitialCards,
+			playerCards:  []Card{{Rank: Two, Suit: Hearts}, {Rank: Three, Suit: Clubs}},
+			handLevels:   map[string]int{},
+			jokers:       jokers,
+			eventEmitter: NewEventEmitter(),
+		}
+		g.eventEmitter.SetEventHandler(handler)
+		g.handlePlayAction([]string{"1"})
+		for _, e := range handler.events {
+			if hp, ok := e.(HandPlayedEvent); ok {
+				return hp
+			}
+		}
+		t.Fatal("no hand played")
+		return HandPlayedEvent{}
+	}
+	plus := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Plus", Effect: AddMult, EffectMagnitude: 8}))
+	times := newOwnedJoker(createJokerFromConfig(JokerConfig{Name: "Times", Effect: MultiplyMult, EffectMagnitude: 2}))
+
+	first := play([]OwnedJoker{plus, times})
+	g := &Game{jokers: []OwnedJoker{plus, times}, eventEmitter: NewEventEmitter()}
+	g.handleMoveJokerAction([]string{"1", "down"})
+	second := play(g.jokers)
+
+	base := Score(first.Multiplier)
+	if first.FinalMult != (base+8)*2 || second.FinalMult != base*2+8 {
+		t.Fatalf("final mult = %s then %s, want %s then %s", first.FinalMult, second.FinalMult, (base+8)*2, base*2+8)
+	}
+	if first.FinalScore <= second.FinalScore {
+		t.Errorf("expected +Mult before ×Mult to score more: %s vs %s", first.FinalScore, second.FinalScore)
+	}
+	if len(first.Steps) != 2 || first.Steps[0].Joker != "Plus" || second.Steps[0].Joker != "Times" {
+		t.Errorf("expected the steps to follow joker order, got %+v and %+v", first.Steps, second.Steps)
+	}
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// VoucherEffect identifies what a voucher upgrades
+type VoucherEffect string
+
+const (
+	// VoucherShopSlots adds card slots to the shop
+	VoucherShopSlots VoucherEffect = "shop_slots"
+	// VoucherDiscount takes a percentage off shop cards, packs and vouchers
+	VoucherDiscount VoucherEffect = "discount"
+	// VoucherHands adds hands to every blind
+	VoucherHands VoucherEffect = "hands"
+	// VoucherDiscards adds discards to every blind
+	VoucherDiscards VoucherEffect = "discards"
+	// VoucherConsumableSlots adds consumable slots
+	VoucherConsumableSlots VoucherEffect = "consumable_slots"
+	// VoucherSpectralInArcana gives Arcana Pack cards a percentage chance to
+	// be Spectral cards
+	VoucherSpectralInArcana VoucherEffect = "spectral_in_arcana"
+	// VoucherInterestCap raises the interest cap
+	VoucherInterestCap VoucherEffect = "interest_cap"
+	// VoucherRerollDiscount lowers the cost of rerolls
+	VoucherRerollDiscount VoucherEffect = "reroll_discount"
+	// VoucherJokerSlots adds joker slots
+	VoucherJokerSlots VoucherEffect = "joker_slots"
+)
+
+// VoucherPrice is what a voucher costs before discounts
+const VoucherPrice = 10
+
+// Voucher is a permanent upgrade for the rest of the run. The shop offers
+// one per ante.
+type Voucher struct {
+	Name        string
+	Description string
+	Effect      VoucherEffect
+	Amount      int
+	// Requires names the voucher that must be redeemed first
+	Requires string
+}
+
+// allVouchers lists every voucher; each tier-2 voucher follows the tier-1
+// voucher it requires
+var allVouchers = []Voucher{
+	{Name: "Overstock", Description: "+1 card slot in the shop", Effect: VoucherShopSlots, Amount: 1},
+	{Name: "Overstock Plus", Description: "+1 more card slot in the shop", Effect: VoucherShopSlots, Amount: 1, Requires: "Overstock"},
+	{Name: "Clearance Sale", Description: "Everything in the shop is 25% off", Effect: VoucherDiscount, Amount: 25},
+	{Name: "Liquidation", Description: "Everything in the shop is 50% off", Effect: VoucherDiscount, Amount: 25, Requires: "Clearance Sale"},
+	{Name: "Grabber", Description: "+1 hand every blind", Effect: VoucherHands, Amount: 1},
+	{Name: "Nacho Tong", Description: "+1 more hand every blind", Effect: VoucherHands, Amount: 1, Requires: "Grabber"},
+	{Name: "Wasteful", Description: "+1 discard every blind", Effect: VoucherDiscards, Amount: 1},
+	{Name: "Recyclomancy", Description: "+1 more discard every blind", Effect: VoucherDiscards, Amount: 1, Requires: "Wasteful"},
+	{Name: "Crystal Ball", Description: "+1 consumable slot", Effect: VoucherConsumableSlots, Amount: 1},
+	{Name: "Omen Globe", Description: "Arcana Pack cards have a 20% chance to be Spectral cards", Effect: VoucherSpectralInArcana, Amount: 20, Requires: "Crystal Ball"},
+	{Name: "Seed Money", Description: "Raise the interest cap by $5", Effect: VoucherInterestCap, Amount: 5},
+	{Name: "Money Tree", Description: "Raise the interest cap by $10 more", Effect: VoucherInterestCap, Amount: 10, Requires: "Seed Money"},
+	{Name: "Reroll Surplus", Description: "Rerolls cost $2 less", Effect: VoucherRerollDiscount, Amount: 2},
+	{Name: "Reroll Glut", Description: "Rerolls cost $2 less again", Effect: VoucherRerollDiscount, Amount: 2, Requires: "Reroll Surplus"},
+	{Name: "Blank", Description: "Does nothing?"},
+	{Name: "Antimatter", Description: "+1 joker slot", Effect: VoucherJokerSlots, Amount: 1, Requires: "Blank"},
+}
+
+// GetVouchers returns every voucher
+func GetVouchers() []Voucher {
+	return append([]Voucher{}, allVouchers...)
+}
+
+// GetVoucherByName returns a voucher by its name if it exists
+func GetVoucherByName(name string) (Voucher, bool) {
+	for _, v := range allVouchers {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Voucher{}, false
+}
+
+// hasVoucher reports whether the player has redeemed the named voucher
+func (g *Game) hasVoucher(name string) bool {
+	for _, v := range g.vouchers {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// voucherBonus sums the amounts of the player's vouchers with an effect
+func (g *Game) voucherBonus(effect VoucherEffect) int {
+	total := 0
+	for _, v := range g.vouchers {
+		if v.Effect == effect {
+			total += v.Amount
+		}
+	}
+	return total
+}
+
+// voucherForAnte returns the voucher the shop offers this ante, if any. It
+// is seeded by the run seed so the offer doesn't change when a run is
+// reloaded, and there is none once this ante's voucher has been bought.
+func (g *Game) voucherForAnte() (Voucher, bool) {
+	if g.voucherAnte == g.currentAnte {
+		return Voucher{}, false
+	}
+	var offers []Voucher
+	for _, v := range allVouchers {
+		if g.hasVoucher(v.Name) || (v.Requires != "" && !g.hasVoucher(v.Requires)) {
+			continue
+		}
+		offers = append(offers, v)
+	}
+	if len(offers) == 0 {
+		return Voucher{}, false
+	}
+	r := rand.New(rand.NewSource(GetSeed() + int64(g.currentAnte)*5))
+	return offers[r.Intn(len(offers))], true
+}
+
+// redeemVoucher gives the player a voucher and uses up this ante's offer
+func (g *Game) redeemVoucher(v Voucher) MessageEvent {
+	g.vouchers = append(g.vouchers, v)
+	g.voucherAnte = g.currentAnte
+	return MessageEvent{Message: fmt.Sprintf("Redeemed %s: %s", v.Name, v.Description), Type: "success"}
+}
+
+// shopPrice applies voucher discounts to a price, rounding to the nearest
+// dollar but never making a paid item free
+func (g *Game) shopPrice(price int) int {
+	discount := g.voucherBonus(VoucherDiscount)
+	if discount == 0 || price <= 0 {
+		return price
+	}
+	discounted := (price*(100-discount) + 50) / 100
+	if discounted < 1 {
+		discounted = 1
+	}
+	return discounted
+}
@@ -0,0 +1,115 @@
+package game
+
+import "testing"
+
+// redeem gives the game the named vouchers
+func redeem(t *testing.T, g *Game, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		v, ok := GetVoucherByName(name)
+		if !ok {
+			t.Fatalf("unknown voucher %s", name)
+		}
+		g.vouchers = append(g.vouchers, v)
+	}
+}
+
+// TestVoucherOfferRequiresTier1 verifies tier-2 vouchers are only offered
+// once their tier-1 voucher is redeemed and owned vouchers aren't offered.
+func TestVoucherOfferRequiresTier1(t *testing.T) {
+	for ante := 1; ante <= 20; ante++ {
+		g := &Game{currentAnte: ante}
+		v, ok := g.voucherForAnte()
+		if !ok || v.Requires != "" {
+			t.Fatalf("ante %d: expected a tier-1 voucher, got %+v", ante, v)
+		}
+	}
+
+	g := &Game{currentAnte: 1}
+	for _, v := range allVouchers {
+		if v.Requires == "" {
+			redeem(t, g, v.Name)
+		}
+	}
+	v, ok := g.voucherForAnte()
+	if !ok || v.Requires == "" {
+		t.Fatalf("expected a tier-2 voucher once every tier-1 is owned, got %+v", v)
+	}
+
+	redeem(t, g, v.Name)
+	g.voucherAnte = g.currentAnte
+	if _, ok := g.voucherForAnte(); ok {
+		t.Fatalf("expected no voucher after this ante's was bought")
+	}
+}
+
+// TestVouchersModifyLimits verifies vouchers change the values they upgrade.
+func TestVouchersModifyLimits(t *testing.T) {
+	g := &Game{}
+	redeem(t, g, "Grabber", "Nacho Tong", "Wasteful", "Overstock", "Crystal Ball", "Seed Money", "Reroll Surplus", "Reroll Glut")
+
+	if g.maxHands() != MaxHands+2 {
+		t.Errorf("max hands = %d, want %d", g.maxHands(), MaxHands+2)
+	}
+	if g.maxDiscards() != MaxDiscards+1 {
+		t.Errorf("max discards = %d, want %d", g.maxDiscards(), MaxDiscards+1)
+	}
+	if g.shopSlots() != GetShopConfig().Slots+1 {
+		t.Errorf("shop slots = %d, want %d", g.shopSlots(), GetShopConfig().Slots+1)
+	}
+	if g.consumableSlots() != DefaultConsumableSlots+1 {
+		t.Errorf("consumable slots = %d, want %d", g.consumableSlots(), DefaultConsumableSlots+1)
+	}
+	if g.interestCap() != DefaultInterestCap+5 {
+		t.Errorf("interest cap = %d, want %d", g.interestCap(), DefaultInterestCap+5)
+	}
+	if g.baseRerollCost() != BaseRerollCost-4 {
+		t.Errorf("base reroll cost = %d, want %d", g.baseRerollCost(), BaseRerollCost-4)
+	}
+}
+
+// TestClearanceSaleDiscounts verifies discounts round to the nearest dollar
+// and never make a paid item free.
+func TestClearanceSaleDiscounts(t *testing.T) {
+	g := &Game{}
+	redeem(t, g, "Clearance Sale")
+	for price, want := range map[int]int{10: 8, 6: 5, 1: 1, 0: 0} {
+		if got := g.shopPrice(price); got != want {
+			t.Errorf("25%% off $%d = $%d, want $%d", price, got, want)
+		}
+	}
+	redeem(t, g, "Liquidation")
+	if got := g.shopPrice(10); got != 5 {
+		t.Errorf("50%% off $10 = $%d, want $5", got)
+	}
+}
+
+// TestBuyVoucherOncePerAnte verifies buying the voucher applies it to the
+// current shop and leaves no voucher until the next ante.
+func TestBuyVoucherOncePerAnte(t *testing.T) {
+	g := createTestGame(nil)
+	g.currentAnte = 1
+	shop := g.newShop()
+	if len(shop.Vouchers) != 1 {
+		t.Fatalf("expected a voucher for sale, got %v", shop.Vouchers)
+	}
+
+	overstock, _ := GetVoucherByName("Overstock")
+	shop.Vouchers[0] = ShopItem{Voucher: &overstock, VoucherPrice: VoucherPrice}
+	slots := len(shop.Items)
+	shop.Buy(len(shop.stock()))
+	if !g.hasVoucher("Overstock") || g.money != 10 {
+		t.Fatalf("expected Overstock to be redeemed for $%d, vouchers=%v money=%d", VoucherPrice, g.vouchers, g.money)
+	}
+	if len(shop.Items) != slots+1 {
+		t.Fatalf("expected Overstock to add a slot to this shop, got %d items", len(shop.Items))
+	}
+
+	if len(g.newShop().Vouchers) != 0 {
+		t.Fatalf("expected no voucher for the rest of the ante")
+	}
+	g.currentAnte = 2
+	if len(g.newShop().Vouchers) != 1 {
+		t.Fatalf("expected a new voucher next ante")
+	}
+}
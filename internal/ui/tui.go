@@ -18,18 +18,25 @@ import (
 type gameStartedMsg struct{}
 type gameOverMsg game.GameOverEvent
 type victoryMsg struct{}
+type endlessModeStartedMsg game.EndlessModeStartedEvent
 type gameStateChangedMsg game.GameStateChangedEvent
 type cardsDealtMsg game.CardsDealtEvent
 type handPlayedMsg game.HandPlayedEvent
 type cardsDiscardedMsg game.CardsDiscardedEvent
 type cardsResortedMsg game.CardsResortedEvent
+type deckViewedMsg game.DeckViewedEvent
 type blindDefeatedMsg game.BlindDefeatedEvent
 type anteCompletedMsg game.AnteCompletedEvent
 type newBlindStartedMsg game.NewBlindStartedEvent
+type blindSelectionMsg game.BlindSelectionEvent
+type blindSkippedMsg game.BlindSkippedEvent
 type shopOpenedMsg game.ShopOpenedEvent
 type shopItemPurchasedMsg game.ShopItemPurchasedEvent
 type shopRerolledMsg game.ShopRerolledEvent
 type shopClosedMsg struct{}
+type packOpenedMsg game.PackOpenedEvent
+type packCardPickedMsg game.PackCardPickedEvent
+type packClosedMsg game.PackClosedEvent
 type invalidActionMsg game.InvalidActionEvent
 type messageEventMsg game.MessageEvent
 type playerActionRequestMsg PlayerActionRequest
@@ -58,11 +65,16 @@ type TUIModel struct {
 	displayMap []int
 	sortMode   string
 	shopInfo   *game.ShopOpenedEvent
+	deckView   *game.DeckViewedEvent
+	blinds     *game.BlindSelectionEvent
+	cashOut    *game.BlindDefeatedEvent
+	pack       *game.PackOpenedEvent
 	mode       Mode
 
 	// Communication with game
 	actionRequestPending *PlayerActionRequest
 	program              *tea.Program
+	startGame            func(config game.RunConfig) error
 
 	viewport viewport.Model
 }
@@ -154,7 +166,7 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case gameStateChangedMsg:
 		event := game.GameStateChangedEvent(msg)
 		m.gameState = event
-		m.logEvent(fmt.Sprintf("Game state: score %d/%d, hands %d, discards %d", event.Score, event.Target, event.Hands, event.Discards))
+		m.logEvent(fmt.Sprintf("Game state: score %s/%s, hands %d, discards %d", event.Score, event.Target, event.Hands, event.Discards))
 		return m, nil
 
 	case cardsDealtMsg:
@@ -166,11 +178,15 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.sortMode = event.SortMode
 		m.logEvent(fmt.Sprintf("Dealt %d cards (sorted by %s)", len(event.Cards), event.SortMode))
 		if len(m.selectedCardCache) > 0 {
+			// Match by rank and suit: a card's debuffed or forced flag can
+			// change after it was selected
 			var newSelection []int
+			taken := make(map[int]bool)
 			for _, sel := range m.selectedCardCache {
 				for idx, card := range m.cards {
-					if card == sel {
+					if card.Rank == sel.Rank && card.Suit == sel.Suit && !taken[idx] {
 						newSelection = append(newSelection, idx)
+						taken[idx] = true
 						break
 					}
 				}
@@ -178,6 +194,7 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.selectedCards = newSelection
 			m.selectedCardCache = nil
 		}
+		m.selectForcedCards()
 		return m, nil
 
 	case handPlayedMsg:
@@ -187,15 +204,17 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		scoreGained := event.FinalScore
 
 		// Check if this completed the blind
-		if event.NewTotalScore >= m.gameState.Target {
-			message = fmt.Sprintf("🎉 %s for +%d points! BLIND DEFEATED!", event.HandType, scoreGained)
+		if event.NotAllowed != "" {
+			message = fmt.Sprintf("🚫 %s not allowed: %s. No points scored", event.HandType, event.NotAllowed)
+		} else if event.NewTotalScore >= m.gameState.Target {
+			message = fmt.Sprintf("🎉 %s for +%s points! BLIND DEFEATED!", event.HandType, scoreGained)
 		} else {
 			handsLeft := m.gameState.Hands - 1
 			if handsLeft <= 0 {
-				message = fmt.Sprintf("💀 %s for +%d points, but Game Over! Final: %d/%d", event.HandType, scoreGained, event.NewTotalScore, m.gameState.Target)
+				message = fmt.Sprintf("💀 %s for +%s points, but Game Over! Final: %s/%s", event.HandType, scoreGained, event.NewTotalScore, m.gameState.Target)
 			} else {
 				progressPercent := float64(event.NewTotalScore) / float64(m.gameState.Target) * 100
-				message = fmt.Sprintf("✅ %s for +%d points! %d/%d (%.0f%%) | %d hands left", event.HandType, scoreGained, event.NewTotalScore, m.gameState.Target, progressPercent, handsLeft)
+				message = fmt.Sprintf("✅ %s for +%s points! %s/%s (%.0f%%) | %d hands left", event.HandType, scoreGained, event.NewTotalScore, m.gameState.Target, progressPercent, handsLeft)
 			}
 		}
 		m.setStatusMessage(message)
@@ -233,6 +252,12 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.logEvent(msgStr)
 		return m, nil
 
+	case deckViewedMsg:
+		event := game.DeckViewedEvent(msg)
+		m.deckView = &event
+		m.logEvent(fmt.Sprintf("Viewing deck: %d cards remaining", len(event.Remaining)))
+		return m, nil
+
 	case blindDefeatedMsg:
 		event := game.BlindDefeatedEvent(msg)
 		// Update money immediately when blind is defeated so the
@@ -248,8 +273,11 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case game.BossBlind:
 			message = "💀 BOSS BLIND ANNIHILATED! 💀"
 		}
+		m.cashOut = &event
+		m.mode = CashOutMode{}
 		m.setStatusMessage(message)
 		m.logEvent(message)
+		m.logEvent(rewardBreakdown(event))
 		return m, nil
 
 	case anteCompletedMsg:
@@ -270,14 +298,34 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case game.BossBlind:
 			blindEmoji = "💀"
 		}
-		msgStr := fmt.Sprintf("%s NOW ENTERING: %s (Ante %d) | Target: %d points", blindEmoji, event.Blind, event.Ante, event.Target)
+		blindName := event.Blind.String()
+		if event.Blind == game.BossBlind && event.Boss != nil && event.Boss.Final {
+			blindEmoji = "🔥"
+			blindName = "SHOWDOWN " + blindName
+		}
+		msgStr := fmt.Sprintf("%s NOW ENTERING: %s (Ante %d) | Target: %s points", blindEmoji, blindName, event.Ante, event.Target)
 		if event.Blind == game.BossBlind && event.Boss != nil {
-			msgStr += fmt.Sprintf(" | Boss: %s - %s", event.Boss.Name, event.Boss.Effect)
+			msgStr += fmt.Sprintf(" | Boss: %s - %s", event.Boss.Name, event.Boss.Describe())
 		}
 		m.setStatusMessage(msgStr)
 		m.logEvent(msgStr)
 		return m, nil
 
+	case blindSelectionMsg:
+		event := game.BlindSelectionEvent(msg)
+		m.blinds = &event
+		m.mode = BlindSelectMode{}
+		m.setStatusMessage(fmt.Sprintf("🎲 Ante %d: play or skip the %s", event.Ante, event.Current))
+		m.logEvent(fmt.Sprintf("Choosing blind: %s", event.Current))
+		return m, nil
+
+	case blindSkippedMsg:
+		event := game.BlindSkippedEvent(msg)
+		msgStr := fmt.Sprintf("⏭️ Skipped the %s for the %s", event.Blind, event.Tag.Name())
+		m.setStatusMessage(msgStr)
+		m.logEvent(msgStr)
+		return m, nil
+
 	case shopOpenedMsg:
 		event := game.ShopOpenedEvent(msg)
 		shopCopy := event
@@ -295,6 +343,7 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.gameState.Money = event.RemainingMoney
 		if m.shopInfo != nil {
 			m.shopInfo.Money = event.RemainingMoney
+			m.shopInfo.Items = event.Items
 		}
 		msgStr := fmt.Sprintf("✨ Purchased %s! Remaining: $%d", event.Item.Name, event.RemainingMoney)
 		m.setStatusMessage(msgStr)
@@ -321,6 +370,32 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.mode = GameMode{}
 		return m, nil
 
+	case packOpenedMsg:
+		event := game.PackOpenedEvent(msg)
+		m.pack = &event
+		m.mode = &PackMode{}
+		msgStr := fmt.Sprintf("📦 Opened %s!", event.Pack)
+		m.setStatusMessage(msgStr)
+		m.logEvent(msgStr)
+		return m, nil
+
+	case packCardPickedMsg:
+		event := game.PackCardPickedEvent(msg)
+		if m.pack != nil {
+			m.pack.Cards = event.Cards
+			m.pack.PicksLeft = event.PicksLeft
+		}
+		msgStr := fmt.Sprintf("✨ Took %s", event.Card.Name)
+		m.setStatusMessage(msgStr)
+		m.logEvent(msgStr)
+		return m, nil
+
+	case packClosedMsg:
+		m.pack = nil
+		m.mode = &ShoppingMode{}
+		m.logEvent(fmt.Sprintf("Closed %s", game.PackClosedEvent(msg).Pack))
+		return m, nil
+
 	case invalidActionMsg:
 		event := game.InvalidActionEvent(msg)
 		msgStr := fmt.Sprintf("❌ %s", event.Reason)
@@ -349,7 +424,7 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case gameOverMsg:
 		event := game.GameOverEvent(msg)
-		msgStr := fmt.Sprintf("💀 GAME OVER! Final: %d/%d (Ante %d)", event.FinalScore, event.Target, event.Ante)
+		msgStr := fmt.Sprintf("💀 GAME OVER! Final: %s/%s (Ante %d)", event.FinalScore, event.Target, event.Ante)
 		m.setStatusMessage(msgStr)
 		m.logEvent(msgStr)
 		return m, nil
@@ -358,6 +433,14 @@ func (m TUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		msgStr := "🏆 VICTORY! You conquered all 8 Antes! 🎉"
 		m.setStatusMessage(msgStr)
 		m.logEvent("Victory achieved")
+		m.mode = EndlessOfferMode{}
+		return m, nil
+
+	case endlessModeStartedMsg:
+		event := game.EndlessModeStartedEvent(msg)
+		msgStr := fmt.Sprintf("♾️ ENDLESS MODE! Ante %d and beyond", event.Ante)
+		m.setStatusMessage(msgStr)
+		m.logEvent(msgStr)
 		return m, nil
 
 	case playerActionRequestMsg:
@@ -557,6 +640,10 @@ func (m *TUIModel) toggleCardSelection(index int) {
 		if selected == index {
 			// Remove from selection
 			card := m.cards[index]
+			if card.Forced {
+				m.setStatusMessage(fmt.Sprintf("🔔 %s is forced by the boss and can't be deselected", card.String()))
+				return
+			}
 			m.selectedCards = append(m.selectedCards[:i], m.selectedCards[i+1:]...)
 			remaining := len(m.selectedCards)
 			if remaining > 0 {
@@ -579,6 +666,15 @@ func (m *TUIModel) toggleCardSelection(index int) {
 	m.setStatusMessage(fmt.Sprintf("✓ Selected %s (card %d) | %d/5 cards selected", card.String(), index+1, len(m.selectedCards)))
 }
 
+// selectForcedCards adds cards the boss forces to be selected to the selection
+func (m *TUIModel) selectForcedCards() {
+	for i, card := range m.cards {
+		if card.Forced && !m.isCardSelected(i) {
+			m.selectedCards = append(m.selectedCards, i)
+		}
+	}
+}
+
 // handlePlay processes playing the selected cards
 func (m *TUIModel) handlePlay() {
 	if len(m.selectedCards) == 0 {
@@ -678,8 +774,9 @@ func (m *TUIModel) SendMessage(msg tea.Msg) {
 	}
 }
 
-// RunTUI starts the TUI application
-func RunTUI() error {
+// RunTUI starts the TUI application. When no deck is configured the player
+// picks a starting deck and stake on the new-run screen before the game begins.
+func RunTUI(config game.RunConfig) error {
 	// Create TUI model
 	model := TUIModel{
 		timeoutDuration: getTimeoutDuration(),
@@ -688,25 +785,64 @@ func RunTUI() error {
 		selectedCards:   []int{},
 		eventLog:        []string{},
 	}
-	// model.Init()
+
+	// Create TUI event handler
+	eventHandler := NewTUIEventHandler()
+
+	// Starting the game is deferred until a deck has been chosen
+	model.startGame = func(runConfig game.RunConfig) error {
+		g, err := game.NewGameWithConfig(eventHandler, runConfig)
+		if err != nil {
+			return err
+		}
+		go g.Run()
+		return nil
+	}
+
+	if config.Deck == "" {
+		if err := game.LoadDeckConfigs(); err != nil {
+			return err
+		}
+		model.mode = NewNewRunMode(game.GetAvailableDecks(), config.Stake)
+	}
 
 	// Create TUI program
 	program := tea.NewProgram(model, tea.WithAltScreen())
 
 	// Set the program reference so we can send messages
 	model.SetProgram(program)
-
-	// Create TUI event handler
-	eventHandler := NewTUIEventHandler()
 	eventHandler.SetTUIModel(&model)
 
-	// Create game with event handler
-	game := game.NewGame(eventHandler)
-
-	// Start the game in a goroutine
-	go game.Run()
+	// Start the game right away when the deck was given up front
+	if config.Deck != "" {
+		if err := model.startGame(config); err != nil {
+			return err
+		}
+	}
 
 	// Run the TUI
 	_, err := program.Run()
 	return err
 }
+
+// rewardBreakdown summarizes the money earned for defeating a blind
+func rewardBreakdown(e game.BlindDefeatedEvent) string {
+	parts := []string{fmt.Sprintf("Base $%d", e.BaseReward)}
+	if e.BonusReward > 0 {
+		parts = append(parts, fmt.Sprintf("Unused $%d", e.BonusReward))
+	}
+	if e.JokerReward > 0 {
+		parts = append(parts, fmt.Sprintf("Jokers $%d", e.JokerReward))
+	}
+	if e.Interest > 0 {
+		parts = append(parts, fmt.Sprintf("Interest $%d", e.Interest))
+	}
+	if e.TagReward > 0 {
+		parts = append(parts, fmt.Sprintf("Tags $%d", e.TagReward))
+	}
+	breakdown := strings.Join(parts, " + ")
+	if e.RentalCost > 0 {
+		breakdown += fmt.Sprintf(" - Rentals $%d", e.RentalCost)
+	}
+	return fmt.Sprintf("💰 Earned $%d: %s", e.TotalReward, breakdown)
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// BlindSelectMode shows the ante's upcoming blinds and lets the player play
+// the current one or skip it for a tag.
+type BlindSelectMode struct{}
+
+func (bm BlindSelectMode) renderContent(m TUIModel) string {
+	if m.blinds == nil {
+		return gameInfoStyle.Render("Waiting for the next blind...")
+	}
+
+	var columns []string
+	for _, b := range m.blinds.Blinds {
+		columns = append(columns, renderBlindPreview(b, m.blinds.Current))
+	}
+
+	header := fmt.Sprintf("🎲 Ante %d - choose your next blind", m.blinds.Ante)
+	tags := "🏷️ Tags: None"
+	if len(m.blinds.Tags) > 0 {
+		var names []string
+		for _, t := range m.blinds.Tags {
+			names = append(names, t.Name())
+		}
+		tags = "🏷️ Tags: " + strings.Join(names, ", ")
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		gameInfoStyle.Render(header),
+		lipgloss.JoinHorizontal(lipgloss.Top, columns...),
+		gameInfoStyle.Render(tags),
+	)
+}
+
+// renderBlindPreview renders one blind's column, highlighting the blind on offer
+func renderBlindPreview(b game.BlindPreview, current game.BlindType) string {
+	lines := []string{b.Blind.String(), fmt.Sprintf("Target: %s", b.Target), fmt.Sprintf("Reward: $%d", b.Reward)}
+	if b.Boss != nil {
+		lines = append(lines, fmt.Sprintf("👑 %s", b.Boss.Name), b.Boss.Describe())
+	}
+	if b.Tag != nil {
+		lines = append(lines, fmt.Sprintf("Skip: 🏷️ %s", b.Tag.Name()), b.Tag.Description())
+	}
+
+	style := blindPreviewStyle
+	switch {
+	case b.Blind == current:
+		style = style.BorderForeground(lipgloss.Color("226"))
+		lines[0] = "▶ " + lines[0]
+	case b.Blind < current:
+		style = style.Faint(true)
+		lines[0] = "✓ " + lines[0]
+	}
+	return style.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (bm BlindSelectMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "enter", "p":
+		m.sendAction(game.PlayerActionSelectBlind, nil)
+		m.mode = GameMode{}
+	case "s":
+		if m.blinds != nil && m.blinds.Current == game.BossBlind {
+			m.setStatusMessage("The Boss Blind can't be skipped")
+			return m, nil
+		}
+		m.sendAction(game.PlayerActionSkipBlind, nil)
+	}
+	return m, nil
+}
+
+func (bm BlindSelectMode) toggleHelp() Mode {
+	return bm
+}
+
+func (bm BlindSelectMode) getControls() string {
+	return " | Enter/P: play blind, S: skip for tag, Q: quit"
+}
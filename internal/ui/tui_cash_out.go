@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// CashOutMode lists the rewards for a defeated blind and waits for the
+// player to collect them before the shop opens.
+type CashOutMode struct{}
+
+// cashOutLabelWidth leaves room for the amount column inside cashOutStyle
+const cashOutLabelWidth = 36
+
+func (cm CashOutMode) renderContent(m TUIModel) string {
+	if m.cashOut == nil {
+		return gameInfoStyle.Render("Counting your rewards...")
+	}
+
+	lines := []string{fmt.Sprintf("💰 Cash Out - %s defeated", m.cashOut.BlindType), ""}
+	for _, line := range m.cashOut.Lines {
+		lines = append(lines, fmt.Sprintf("%-*s %s", cashOutLabelWidth, line.Label, line.AmountString()))
+	}
+	lines = append(lines,
+		"",
+		fmt.Sprintf("%-*s $%d", cashOutLabelWidth, "Total", m.cashOut.TotalReward),
+		fmt.Sprintf("%-*s $%d", cashOutLabelWidth, "Your money", m.cashOut.NewMoney),
+	)
+	return cashOutStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (cm CashOutMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "enter", " ", "c":
+		m.sendAction(game.PlayerActionCashOut, nil)
+		m.mode = GameMode{}
+	}
+	return m, nil
+}
+
+func (cm CashOutMode) toggleHelp() Mode {
+	return cm
+}
+
+func (cm CashOutMode) getControls() string {
+	return " | Enter/C: cash out, Q: quit"
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// ConsumableMode lists the player's consumables and uses the one picked.
+type ConsumableMode struct {
+	prevMode Mode
+}
+
+// NewConsumableMode returns a ConsumableMode wrapping the previous mode.
+func NewConsumableMode(prev Mode) *ConsumableMode {
+	return &ConsumableMode{prevMode: prev}
+}
+
+// renderConsumables summarizes held consumables on one line, or returns an
+// empty string when there are none
+func renderConsumables(consumables []game.Consumable) string {
+	if len(consumables) == 0 {
+		return ""
+	}
+	var names []string
+	for _, c := range consumables {
+		names = append(names, fmt.Sprintf("%s (%s)", c.Name, c.Description()))
+	}
+	return "🪐 Consumables: " + strings.Join(names, ", ")
+}
+
+func (cm ConsumableMode) renderContent(m TUIModel) string {
+	if len(m.gameState.Consumables) == 0 {
+		return gameInfoStyle.Render("No consumables to use")
+	}
+	var lines []string
+	for i, c := range m.gameState.Consumables {
+		lines = append(lines, fmt.Sprintf("%d. %s [%s] - %s", i+1, c.Name, c.Type, c.Description()))
+	}
+	header := "Use a Consumable"
+	content := lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, lines...)...)
+	return gameInfoStyle.Height(len(lines) + 2).Render(content)
+}
+
+func (cm *ConsumableMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "esc", "enter":
+		m.mode = cm.prevMode
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		idx, _ := strconv.Atoi(msg)
+		if idx > len(m.gameState.Consumables) {
+			m.setStatusMessage(fmt.Sprintf("Invalid consumable number: %s", msg))
+			return m, nil
+		}
+		used := m.gameState.Consumables[idx-1]
+		m.sendAction(game.PlayerActionUseConsumable, []string{msg})
+		m.gameState.Consumables = append(m.gameState.Consumables[:idx-1], m.gameState.Consumables[idx:]...)
+		m.setStatusMessage(fmt.Sprintf("Used %s", used.Name))
+		m.mode = cm.prevMode
+	}
+	return m, nil
+}
+
+func (cm *ConsumableMode) toggleHelp() Mode {
+	return cm
+}
+
+func (cm *ConsumableMode) getControls() string {
+	return " | 1-9: use consumable, Enter/Esc: back"
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// DeckViewMode shows the full deck grouped by suit, dimming cards that have
+// already been drawn this blind.
+type DeckViewMode struct {
+	prevMode Mode
+}
+
+// NewDeckViewMode returns a DeckViewMode wrapping the previous mode.
+func NewDeckViewMode(prev Mode) *DeckViewMode {
+	return &DeckViewMode{prevMode: prev}
+}
+
+func (dm DeckViewMode) renderContent(m TUIModel) string {
+	if m.deckView == nil {
+		return gameInfoStyle.Render("Loading deck...")
+	}
+
+	drawnBySuit := make(map[game.Suit][]game.Card)
+	remainingBySuit := make(map[game.Suit][]game.Card)
+	for _, card := range m.deckView.Drawn {
+		drawnBySuit[card.Suit] = append(drawnBySuit[card.Suit], card)
+	}
+	for _, card := range m.deckView.Remaining {
+		remainingBySuit[card.Suit] = append(remainingBySuit[card.Suit], card)
+	}
+
+	total := len(m.deckView.Drawn) + len(m.deckView.Remaining)
+	lines := []string{fmt.Sprintf("🂠 Deck: %d of %d cards remaining", len(m.deckView.Remaining), total)}
+
+	suitCounts := game.CountBySuit(m.deckView.Remaining)
+	for suit := game.Hearts; suit <= game.Spades; suit++ {
+		var cardViews []string
+		for rank := game.Ace; rank <= game.King; rank++ {
+			for _, card := range remainingBySuit[suit] {
+				if card.Rank == rank {
+					cardViews = append(cardViews, renderCard(m, card, false))
+				}
+			}
+			for _, card := range drawnBySuit[suit] {
+				if card.Rank == rank {
+					cardViews = append(cardViews, drawnCardStyle.Render(card.String()))
+				}
+			}
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Top, cardViews...)
+		lines = append(lines, fmt.Sprintf("%s %2d │ %s", suit, suitCounts[suit], row))
+	}
+
+	rankCounts := game.CountByRank(m.deckView.Remaining)
+	var rankParts []string
+	for rank := game.Ace; rank <= game.King; rank++ {
+		rankParts = append(rankParts, fmt.Sprintf("%s:%d", rank, rankCounts[rank]))
+	}
+	lines = append(lines, "", "Remaining by rank: "+strings.Join(rankParts, " "))
+
+	return gameInfoStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (dm *DeckViewMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "esc", "enter", "v":
+		m.mode = dm.prevMode
+		return m, nil
+	}
+	return m, nil
+}
+
+func (dm *DeckViewMode) toggleHelp() Mode {
+	return dm
+}
+
+func (dm *DeckViewMode) getControls() string {
+	return " | V/Enter/Esc: back"
+}
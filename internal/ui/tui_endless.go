@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// EndlessOfferMode is shown after victory and asks whether to keep playing
+// past the final ante.
+type EndlessOfferMode struct{}
+
+func (em EndlessOfferMode) renderContent(m TUIModel) string {
+	lines := []string{
+		"🏆 VICTORY! You conquered all 8 Antes! 🎉",
+		"",
+		fmt.Sprintf("♾️  Continue into endless mode from Ante %d?", game.MaxAntes+1),
+		"Targets keep growing every ante and bosses keep coming.",
+	}
+	return gameInfoStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (em EndlessOfferMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "enter", "y":
+		m.sendAction(game.PlayerActionContinueEndless, nil)
+		m.mode = GameMode{}
+		m.setStatusMessage("♾️ Entering endless mode...")
+	}
+	return m, nil
+}
+
+func (em EndlessOfferMode) toggleHelp() Mode {
+	return em
+}
+
+func (em EndlessOfferMode) getControls() string {
+	return " | Enter/Y: continue endless | Q: end run"
+}
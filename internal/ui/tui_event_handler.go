@@ -67,6 +67,9 @@ func (h *TUIEventHandler) HandleEvent(event game.Event) {
 	case game.CardsResortedEvent:
 		h.tuiModel.SendMessage(cardsResortedMsg(e))
 
+	case game.DeckViewedEvent:
+		h.tuiModel.SendMessage(deckViewedMsg(e))
+
 	case game.BlindDefeatedEvent:
 		h.tuiModel.SendMessage(blindDefeatedMsg(e))
 
@@ -88,6 +91,15 @@ func (h *TUIEventHandler) HandleEvent(event game.Event) {
 	case game.ShopClosedEvent:
 		h.tuiModel.SendMessage(shopClosedMsg{})
 
+	case game.PackOpenedEvent:
+		h.tuiModel.SendMessage(packOpenedMsg(e))
+
+	case game.PackCardPickedEvent:
+		h.tuiModel.SendMessage(packCardPickedMsg(e))
+
+	case game.PackClosedEvent:
+		h.tuiModel.SendMessage(packClosedMsg(e))
+
 	case game.InvalidActionEvent:
 		h.tuiModel.SendMessage(invalidActionMsg(e))
 
@@ -99,6 +111,15 @@ func (h *TUIEventHandler) HandleEvent(event game.Event) {
 
 	case game.VictoryEvent:
 		h.tuiModel.SendMessage(victoryMsg{})
+
+	case game.EndlessModeStartedEvent:
+		h.tuiModel.SendMessage(endlessModeStartedMsg(e))
+
+	case game.BlindSelectionEvent:
+		h.tuiModel.SendMessage(blindSelectionMsg(e))
+
+	case game.BlindSkippedEvent:
+		h.tuiModel.SendMessage(blindSkippedMsg(e))
 	}
 }
 
@@ -136,6 +157,26 @@ func (h *TUIEventHandler) GetShopAction() (game.PlayerAction, []string, bool) {
 	return action, params, quit
 }
 
+// GetEndlessAction waits for the player to accept or decline endless mode
+func (h *TUIEventHandler) GetEndlessAction() (game.PlayerAction, []string, bool) {
+	return h.GetPlayerAction(false)
+}
+
+// GetBlindAction waits for the player to play or skip the blind on offer
+func (h *TUIEventHandler) GetBlindAction() (game.PlayerAction, []string, bool) {
+	return h.GetPlayerAction(false)
+}
+
+// GetCashOutAction waits for the player to collect a defeated blind's rewards
+func (h *TUIEventHandler) GetCashOutAction() (game.PlayerAction, []string, bool) {
+	return h.GetPlayerAction(false)
+}
+
+// GetPackAction waits for the player to pick a card from an opened booster pack
+func (h *TUIEventHandler) GetPackAction() (game.PlayerAction, []string, bool) {
+	return h.GetPlayerAction(false)
+}
+
 // Close cleans up resources
 func (h *TUIEventHandler) Close() {
 	close(h.actionChan)
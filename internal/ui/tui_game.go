@@ -46,24 +46,23 @@ func (gm GameMode) renderContent(m TUIModel) string {
 	}
 
 	blindText := m.gameState.Blind.String()
+	if m.gameState.Showdown {
+		blindEmoji = "🔥"
+		blindText = "Showdown " + blindText
+	}
 	if m.gameState.Blind == game.BossBlind && m.gameState.Boss != "" {
 		blindText = fmt.Sprintf("%s: %s", blindText, m.gameState.Boss)
 	}
 	gameInfo := fmt.Sprintf("%s Ante %d - %s\n", blindEmoji, m.gameState.Ante, blindText) +
-		fmt.Sprintf("🎯 Target: %d | Current Score: %d [%s] (%.1f%%)\n",
+		fmt.Sprintf("🎯 Target: %s | Current Score: %s [%s] (%.1f%%)\n",
 			m.gameState.Target, m.gameState.Score, progressBar, progress*100) +
 		fmt.Sprintf("🎴 Hands Left: %d | 🗑️ Discards Left: %d | 💰 Money: $%d",
 			m.gameState.Hands, m.gameState.Discards, m.gameState.Money)
 
 	// Add joker information
-	var jokerLines []string
-	if len(m.gameState.Jokers) == 0 {
-		jokerLines = append(jokerLines, "🃏 Jokers: None")
-	} else {
-		jokerLines = append(jokerLines, "🃏 Jokers:")
-		for _, joker := range m.gameState.Jokers {
-			jokerLines = append(jokerLines, renderOwnedJoker(joker))
-		}
+	jokerLines := renderOwnedJokers(m.gameState, false)
+	if line := renderConsumables(m.gameState.Consumables); line != "" {
+		jokerLines = append(jokerLines, line)
 	}
 	gameInfo += "\n" + strings.Join(jokerLines, "\n")
 
@@ -101,6 +100,12 @@ func renderCard(m TUIModel, card game.Card, isInSelectedArea bool) string {
 	case game.Spades:
 		style = spadesCardStyle
 	}
+	if card.Debuffed {
+		style = debuffedCardStyle
+	}
+	if card.Forced {
+		style = style.Underline(true)
+	}
 
 	if isInSelectedArea {
 		style = style.Bold(true).Background(lipgloss.Color("235"))
@@ -147,9 +152,44 @@ func renderHand(m TUIModel) string {
 	return handStyle.Height(10).Render(content.String())
 }
 
-// renderOwnedJoker renders a joker the player currently owns
-func renderOwnedJoker(joker game.Joker) string {
-	return fmt.Sprintf("%s: %s", joker.Name, joker.Description)
+// renderOwnedJokers lists the player's jokers under a header showing how
+// many joker slots are taken, optionally with what each sells for
+func renderOwnedJokers(state game.GameStateChangedEvent, sellValues bool) []string {
+	header := "🃏 Jokers"
+	if state.JokerSlots > 0 {
+		header = fmt.Sprintf("%s (%d/%d)", header, game.JokerSlotsUsed(state.Jokers), state.JokerSlots)
+	}
+	if len(state.Jokers) == 0 {
+		return []string{header + ": None"}
+	}
+	lines := []string{header + ":"}
+	for _, joker := range state.Jokers {
+		line := renderOwnedJoker(joker)
+		if sellValues {
+			line = fmt.Sprintf("%s %s", line, sellValueLabel(joker))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// sellValueLabel describes what a joker sells for
+func sellValueLabel(joker game.OwnedJoker) string {
+	return fmt.Sprintf("(sells for $%d)", joker.SellValue())
+}
+
+// renderOwnedJoker renders a joker the player currently owns, dimming jokers
+// a boss has disabled or flipped face down
+func renderOwnedJoker(joker game.OwnedJoker) string {
+	name, description := joker.Face()
+	line := fmt.Sprintf("%s: %s", name, description)
+	if label := joker.StickerLabel(); label != "" {
+		line = fmt.Sprintf("%s %s", line, label)
+	}
+	if joker.Disabled || joker.FaceDown {
+		return inactiveJokerStyle.Render(line)
+	}
+	return line
 }
 
 func (gm GameMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
@@ -192,8 +232,19 @@ func (gm GameMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd)
 		m.mode = NewJokerOrderMode(gm)
 		return m, nil
 
+	case "u":
+		m.mode = NewConsumableMode(gm)
+		return m, nil
+
+	case "v":
+		m.deckView = nil
+		m.mode = NewDeckViewMode(gm)
+		m.sendAction(game.PlayerActionViewDeck, nil)
+		return m, nil
+
 	case "escape", "c":
 		m.selectedCards = []int{}
+		m.selectForcedCards()
 		m.setStatusMessage("Selection cleared")
 		return m, nil
 	}
@@ -206,7 +257,7 @@ func (gm GameMode) toggleHelp() Mode {
 }
 
 func (gm GameMode) getControls() string {
-	return " | 1-7: select cards, Enter/P: play, D: discard, C: clear, R: resort, J: reorder jokers, H: help, Q: quit"
+	return " | 1-7: select cards, Enter/P: play, D: discard, C: clear, R: resort, J: reorder jokers, U: use consumable, V: view deck, H: help, Q: quit"
 }
 
 type GameHelpMode struct{}
@@ -234,6 +285,8 @@ func (gm GameHelpMode) renderContent(m TUIModel) string {
 		   • Cards: Displayed as compact 2-char format (e.g., A♠, K♥)
 		     - Hearts ♥: Red, Diamonds ♦: Orange
 		     - Clubs ♣: Dark Blue, Spades ♠: Gray
+		     - Struck-through gray cards are debuffed and score nothing
+		     - Underlined cards are forced by the boss and always selected
 
 		🎴 POKER HANDS (from weakest to strongest):
 		   • High Card      • Pair           • Two Pair
@@ -250,6 +303,7 @@ func (gm GameHelpMode) renderContent(m TUIModel) string {
 		   • Enter/P: Play selected cards
 		   • D: Discard selected cards
 		   • C/Escape: Clear selection
+		   • V: View remaining deck
 		   • H: Toggle this help screen
 		   • Q: Quit game
 
@@ -14,6 +14,8 @@ import (
 type JokerOrderMode struct {
 	prevMode Mode
 	selected int // -1 indicates no selection
+	// makeRoomFor names the shop item waiting for a free joker slot
+	makeRoomFor string
 }
 
 // NewJokerOrderMode returns a JokerOrderMode wrapping the previous mode.
@@ -21,13 +23,19 @@ func NewJokerOrderMode(prev Mode) *JokerOrderMode {
 	return &JokerOrderMode{prevMode: prev, selected: -1}
 }
 
+// NewMakeRoomMode returns a JokerOrderMode that asks the player to sell a
+// joker so they can buy item, going back to the shop after the sale.
+func NewMakeRoomMode(prev Mode, item string) *JokerOrderMode {
+	return &JokerOrderMode{prevMode: prev, selected: -1, makeRoomFor: item}
+}
+
 func (jm JokerOrderMode) renderContent(m TUIModel) string {
 	if len(m.gameState.Jokers) == 0 {
 		return gameInfoStyle.Render("No jokers to reorder")
 	}
 	var lines []string
 	for i, j := range m.gameState.Jokers {
-		line := fmt.Sprintf("%d. %s: %s", i+1, j.Name, j.Description)
+		line := fmt.Sprintf("%d. %s %s", i+1, renderOwnedJoker(j), sellValueLabel(j))
 		style := lipgloss.NewStyle()
 		if jm.selected == i {
 			style = style.Foreground(lipgloss.Color("226")).Bold(true)
@@ -35,6 +43,9 @@ func (jm JokerOrderMode) renderContent(m TUIModel) string {
 		lines = append(lines, style.Render(line))
 	}
 	header := "Reorder Jokers"
+	if jm.makeRoomFor != "" {
+		header = fmt.Sprintf("Sell a joker to make room for %s", jm.makeRoomFor)
+	}
 	content := lipgloss.JoinVertical(lipgloss.Left, append([]string{header}, lines...)...)
 	return gameInfoStyle.Height(len(lines) + 2).Render(content)
 }
@@ -59,10 +70,20 @@ func (jm *JokerOrderMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, te
 		}
 		idx := jm.selected
 		joker := m.gameState.Jokers[idx]
+		name, _ := joker.Face()
+		if joker.Sticker == game.EternalSticker {
+			m.setStatusMessage(fmt.Sprintf("%s is Eternal and can't be sold", name))
+			return m, nil
+		}
 		m.sendAction(game.PlayerActionSellJoker, []string{strconv.Itoa(idx + 1)})
 		m.gameState.Jokers = append(m.gameState.Jokers[:idx], m.gameState.Jokers[idx+1:]...)
 		jm.selected = -1
-		m.setStatusMessage(fmt.Sprintf("Sold %s for $%d", joker.Name, joker.Price/2))
+		if jm.makeRoomFor != "" {
+			m.mode = jm.prevMode
+			m.setStatusMessage(fmt.Sprintf("Sold %s for $%d, press Enter to buy %s", name, joker.SellValue(), jm.makeRoomFor))
+			return m, nil
+		}
+		m.setStatusMessage(fmt.Sprintf("Sold %s for $%d", name, joker.SellValue()))
 		return m, nil
 	case "up", "k":
 		if jm.selected == -1 {
@@ -99,5 +120,8 @@ func (jm *JokerOrderMode) toggleHelp() Mode {
 }
 
 func (jm *JokerOrderMode) getControls() string {
+	if jm.makeRoomFor != "" {
+		return " | 1-9: select joker, S: sell to make room, Esc: cancel"
+	}
 	return " | 1-9: select joker, ↑/k: move up, ↓/j: move down, S: sell, Enter/Esc: back"
 }
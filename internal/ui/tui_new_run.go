@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// NewRunMode lets the player choose a starting deck and stake before the run begins.
+type NewRunMode struct {
+	decks    []game.StartingDeck
+	selected int
+	stake    game.Stake
+}
+
+// NewNewRunMode returns a NewRunMode offering the given decks.
+func NewNewRunMode(decks []game.StartingDeck, stake game.Stake) *NewRunMode {
+	return &NewRunMode{decks: decks, stake: stake}
+}
+
+func (nm NewRunMode) renderContent(m TUIModel) string {
+	lines := []string{"🂠 Choose your starting deck", ""}
+	for i, d := range nm.decks {
+		line := fmt.Sprintf("%d. %s: %s", i+1, d.Name, d.Description)
+		style := lipgloss.NewStyle()
+		if nm.selected == i {
+			style = style.Foreground(lipgloss.Color("226")).Bold(true)
+			line = "▶ " + line
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, style.Render(line))
+	}
+	lines = append(lines, "", fmt.Sprintf("◀ %s ▶", nm.stake))
+	for _, s := range game.AllStakes() {
+		if s > nm.stake {
+			break
+		}
+		lines = append(lines, fmt.Sprintf("  • %s", s.Description()))
+	}
+	return gameInfoStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (nm *NewRunMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	m.lastActivity = time.Now()
+
+	switch msg {
+	case "up", "k":
+		if nm.selected > 0 {
+			nm.selected--
+		}
+		return m, nil
+	case "down", "j":
+		if nm.selected < len(nm.decks)-1 {
+			nm.selected++
+		}
+		return m, nil
+	case "left":
+		if nm.stake > game.WhiteStake {
+			nm.stake--
+		}
+		return m, nil
+	case "right":
+		if nm.stake < game.GoldStake {
+			nm.stake++
+		}
+		return m, nil
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		idx := int(msg[0]-'0') - 1
+		if idx < len(nm.decks) {
+			nm.selected = idx
+		} else {
+			m.setStatusMessage(fmt.Sprintf("Invalid deck number: %s", msg))
+		}
+		return m, nil
+	case "enter":
+		if len(nm.decks) == 0 || m.startGame == nil {
+			m.setStatusMessage("No decks available")
+			return m, nil
+		}
+		deck := nm.decks[nm.selected]
+		if err := m.startGame(game.RunConfig{Deck: deck.Name, Stake: nm.stake}); err != nil {
+			m.setStatusMessage(fmt.Sprintf("❌ %v", err))
+			return m, nil
+		}
+		m.startGame = nil
+		m.setStatusMessage(fmt.Sprintf("Starting run with %s on %s", deck.Name, nm.stake))
+		return m, nil
+	}
+	return m, nil
+}
+
+func (nm *NewRunMode) toggleHelp() Mode {
+	return nm
+}
+
+func (nm *NewRunMode) getControls() string {
+	return " | ↑/↓ or 1-9: choose deck, ←/→: choose stake, Enter: start run, Q: quit"
+}
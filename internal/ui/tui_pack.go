@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	game "balatno/internal/game"
+)
+
+// PackMode shows the cards in an opened booster pack and lets the player
+// pick from them.
+type PackMode struct{}
+
+func (pm PackMode) renderContent(m TUIModel) string {
+	if m.pack == nil {
+		return gameInfoStyle.Render("Opening pack...")
+	}
+
+	lines := []string{fmt.Sprintf("📦 %s - choose %d", m.pack.Pack, m.pack.PicksLeft), ""}
+	for i, card := range m.pack.Cards {
+		if card.Name == "" {
+			continue
+		}
+		name := card.Name
+		if card.Edition != game.NoEdition {
+			name = fmt.Sprintf("%s [%s]", name, card.Edition)
+		}
+		lines = append(lines, fmt.Sprintf("%d. %s (%s): %s", i+1, name, card.Type, card.Description))
+	}
+	return packStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
+func (pm *PackMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.Cmd) {
+	m.lastActivity = time.Now()
+
+	switch msg {
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		i := int(msg[0] - '0')
+		if m.pack == nil || i > len(m.pack.Cards) || m.pack.Cards[i-1].Name == "" {
+			m.setStatusMessage("No card there!")
+			return m, nil
+		}
+		m.sendAction(game.PlayerActionPickCard, []string{strconv.Itoa(i)})
+	case "s", "esc":
+		m.sendAction(game.PlayerActionSkipPack, nil)
+		m.setStatusMessage("Skipped the rest of the pack")
+	}
+	return m, nil
+}
+
+func (pm PackMode) toggleHelp() Mode {
+	return &pm
+}
+
+func (pm PackMode) getControls() string {
+	return " | 1-9: take card, S/ESC: skip the rest, Q: quit"
+}
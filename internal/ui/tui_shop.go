@@ -27,14 +27,12 @@ func (ms ShoppingMode) renderContent(m TUIModel) string {
 			m.gameState.Hands, m.gameState.Discards, m.gameState.Money, m.shopInfo.RerollCost)
 
 	// Add joker information
-	var jokerLines []string
-	if len(m.gameState.Jokers) == 0 {
-		jokerLines = append(jokerLines, "🃏 Jokers: None")
-	} else {
-		jokerLines = append(jokerLines, "🃏 Jokers:")
-		for _, joker := range m.gameState.Jokers {
-			jokerLines = append(jokerLines, renderOwnedJoker(joker))
-		}
+	jokerLines := renderOwnedJokers(m.gameState, true)
+	if line := renderConsumables(m.gameState.Consumables); line != "" {
+		jokerLines = append(jokerLines, line)
+	}
+	if line := renderVouchers(m.gameState.Vouchers); line != "" {
+		jokerLines = append(jokerLines, line)
 	}
 	gameInfo += "\n" + strings.Join(jokerLines, "\n")
 
@@ -116,6 +114,13 @@ func (gm *ShoppingMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.
 				return m, nil
 			}
 
+			if jokerSlotsFull(m.gameState, item) {
+				m.mode = NewMakeRoomMode(gm, item.Name)
+				m.setStatusMessage(fmt.Sprintf("Joker slots are full! Sell a joker to make room for %s", item.Name))
+				gm.consecutiveEnters = 0
+				return m, nil
+			}
+
 			m.sendAction(game.PlayerActionBuy, []string{strconv.Itoa(*gm.selectedItem)})
 			m.setStatusMessage(fmt.Sprintf("🛒 Purchased %s!", item.Name))
 			gm.consecutiveEnters = 0
@@ -143,11 +148,37 @@ func (gm *ShoppingMode) handleKeyPress(m *TUIModel, msg string) (tea.Model, tea.
 	case "j":
 		m.mode = NewJokerOrderMode(gm)
 		return m, nil
+
+	case "u":
+		m.mode = NewConsumableMode(gm)
+		return m, nil
 	}
 	gm.consecutiveEnters = 0
 	return m, nil
 }
 
+// jokerSlotsFull reports whether buying the shop item needs a joker slot
+// the player doesn't have
+func jokerSlotsFull(state game.GameStateChangedEvent, item game.ShopItemData) bool {
+	if item.Type != "joker" || item.Edition == game.NegativeEdition || state.JokerSlots == 0 {
+		return false
+	}
+	return game.JokerSlotsUsed(state.Jokers) >= state.JokerSlots
+}
+
+// renderVouchers lists redeemed vouchers on one line, or returns an empty
+// string when there are none
+func renderVouchers(vouchers []game.Voucher) string {
+	if len(vouchers) == 0 {
+		return ""
+	}
+	var names []string
+	for _, v := range vouchers {
+		names = append(names, v.Name)
+	}
+	return "🎟️ Vouchers: " + strings.Join(names, ", ")
+}
+
 func renderJoker(m TUIModel, joker game.ShopItemData) string {
 	if joker.Name == "" {
 		return ""
@@ -156,7 +187,17 @@ func renderJoker(m TUIModel, joker game.ShopItemData) string {
 	if joker.Cost > m.gameState.Money {
 		cost = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render(cost)
 	}
-	jokerStr := fmt.Sprintf("%s ($%s): %s\n", joker.Name, cost, joker.Description)
+	name := joker.Name
+	if joker.Edition != game.NoEdition {
+		name = fmt.Sprintf("%s [%s]", name, joker.Edition)
+	}
+	if joker.Sticker != game.NoSticker {
+		name = fmt.Sprintf("%s [%s]", name, joker.Sticker)
+	}
+	if joker.Type != "" && joker.Type != "joker" {
+		name = fmt.Sprintf("%s (%s)", name, joker.Type)
+	}
+	jokerStr := fmt.Sprintf("%s ($%s): %s\n", name, cost, joker.Description)
 
 	return jokerStr
 }
@@ -167,8 +208,8 @@ func (gm ShoppingMode) toggleHelp() Mode {
 
 func (gm ShoppingMode) getControls() string {
 	// TODO I do think we'll need the game state to know how many shop items are available
-	// but for now hardcode to 4
-	return " | 1-4: select item, Enter (with selected): purchase, Enter twice (without selected): exit, C: clear, R: reroll, J: reorder jokers, H: help, ESC: exit, Q: quit"
+	// but for now hardcode to 7
+	return " | 1-7: select item, Enter (with selected): purchase, Enter twice (without selected): exit, C: clear, R: reroll, J: reorder jokers, U: use consumable, H: help, ESC: exit, Q: quit"
 }
 
 type ShopHelpMode struct{}
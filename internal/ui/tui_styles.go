@@ -50,4 +50,39 @@ var (
 	spadesCardStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("240")).
 			Margin(0, 1)
+
+	debuffedCardStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("242")).
+				Strikethrough(true).
+				Margin(0, 1)
+
+	blindPreviewStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("240")).
+				Padding(0, 1).
+				Margin(0, 1).
+				Width(28)
+
+	cashOutStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("220")).
+			Padding(0, 1).
+			Margin(0, 1).
+			Width(48)
+
+	packStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("135")).
+			Padding(0, 1).
+			Margin(0, 1).
+			Width(48)
+
+	inactiveJokerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("242")).
+				Italic(true)
+
+	drawnCardStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("237")).
+			Faint(true).
+			Margin(0, 1)
 )
@@ -57,6 +57,15 @@ func TestResortPreservesSelection(t *testing.T) {
 	if len(m.selectedCards) != 1 || m.selectedCards[0] != 2 {
 		t.Fatalf("expected selection to move to index 2, got %v", m.selectedCards)
 	}
+
+	m.handleResort()
+	debuffed := []game.Card{m.cards[2], m.cards[0], m.cards[1]}
+	debuffed[0].Debuffed = true
+	model, _ = m.Update(cardsDealtMsg(game.CardsDealtEvent{Cards: debuffed, DisplayMapping: []int{0, 1, 2}, SortMode: "rank"}))
+	m = model.(TUIModel)
+	if len(m.selectedCards) != 1 || m.selectedCards[0] != 0 {
+		t.Fatalf("expected the selection to follow a card that became debuffed, got %v", m.selectedCards)
+	}
 }
 
 // TestHelpToggle ensures that pressing 'h' toggles help mode on and off.
@@ -182,7 +191,7 @@ func TestJokerReorder(t *testing.T) {
 	respChan := make(chan PlayerActionResponse, 1)
 	m := TUIModel{
 		gameState: game.GameStateChangedEvent{
-			Jokers: []game.Joker{{Name: "J1"}, {Name: "J2"}},
+			Jokers: []game.OwnedJoker{{Joker: game.Joker{Name: "J1"}}, {Joker: game.Joker{Name: "J2"}}},
 		},
 		mode:                 GameMode{},
 		actionRequestPending: &PlayerActionRequest{ResponseChan: respChan},
@@ -234,7 +243,7 @@ func TestJokerSell(t *testing.T) {
 	m := TUIModel{
 		gameState: game.GameStateChangedEvent{
 			Money:  10,
-			Jokers: []game.Joker{{Name: "J1", Price: 6}, {Name: "J2", Price: 8}},
+			Jokers: []game.OwnedJoker{{Joker: game.Joker{Name: "J1", Price: 6}, PurchasePrice: 6}, {Joker: game.Joker{Name: "J2", Price: 8}, PurchasePrice: 8}},
 		},
 		mode:                 GameMode{},
 		actionRequestPending: &PlayerActionRequest{ResponseChan: respChan},
@@ -259,7 +268,7 @@ func TestJokerSell(t *testing.T) {
 func TestShoppingModeRendersOwnedJokers(t *testing.T) {
 	m := TUIModel{
 		gameState: game.GameStateChangedEvent{
-			Jokers: []game.Joker{{Name: "J1", Description: "desc"}},
+			Jokers: []game.OwnedJoker{{Joker: game.Joker{Name: "J1", Description: "desc"}}},
 		},
 		shopInfo: &game.ShopOpenedEvent{Money: 10, RerollCost: 5},
 	}
@@ -269,3 +278,253 @@ func TestShoppingModeRendersOwnedJokers(t *testing.T) {
 		t.Fatalf("expected owned joker to be rendered, got %s", output)
 	}
 }
+
+// TestDeckViewMode ensures the deck view requests the deck and renders remaining counts.
+func TestDeckViewMode(t *testing.T) {
+	respChan := make(chan PlayerActionResponse, 1)
+	m := TUIModel{
+		mode:                 GameMode{},
+		actionRequestPending: &PlayerActionRequest{ResponseChan: respChan},
+	}
+
+	model, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	m = *(model.(*TUIModel))
+	if _, ok := m.mode.(*DeckViewMode); !ok {
+		t.Fatalf("expected mode to be DeckViewMode")
+	}
+	resp := <-respChan
+	if resp.Action != game.PlayerActionViewDeck {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	event := game.DeckViewedEvent{
+		Drawn:     []game.Card{{Rank: game.Ace, Suit: game.Spades}},
+		Remaining: []game.Card{{Rank: game.Two, Suit: game.Spades}, {Rank: game.Three, Suit: game.Hearts}},
+	}
+	updated, _ := m.Update(deckViewedMsg(event))
+	m = updated.(TUIModel)
+	output := m.mode.renderContent(m)
+	if !strings.Contains(output, "2 of 3 cards remaining") {
+		t.Fatalf("expected remaining count in deck view, got %s", output)
+	}
+
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	m = *(model.(*TUIModel))
+	if _, ok := m.mode.(GameMode); !ok {
+		t.Fatalf("expected to return to GameMode, got %T", m.mode)
+	}
+}
+
+// TestNewRunModeStartsSelectedDeck ensures choosing a deck and stake starts the run with them.
+func TestNewRunModeStartsSelectedDeck(t *testing.T) {
+	var started game.RunConfig
+	m := TUIModel{
+		mode: NewNewRunMode([]game.StartingDeck{{Name: "Standard Deck"}, {Name: "Red Deck"}}, game.WhiteStake),
+		startGame: func(config game.RunConfig) error {
+			started = config
+			return nil
+		},
+	}
+
+	model, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	m = *(model.(*TUIModel))
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRight})
+	m = *(model.(*TUIModel))
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *(model.(*TUIModel))
+
+	if started.Deck != "Red Deck" || started.Stake != game.RedStake {
+		t.Fatalf("expected Red Deck on Red Stake to be started, got %+v", started)
+	}
+	if m.startGame != nil {
+		t.Fatalf("expected startGame to be cleared after starting")
+	}
+}
+
+// TestForcedCardStaysSelected verifies a card forced by the boss can't be deselected.
+func TestForcedCardStaysSelected(t *testing.T) {
+	m := TUIModel{}
+	updated, _ := m.Update(cardsDealtMsg{Cards: []game.Card{
+		{Rank: game.Two, Suit: game.Hearts},
+		{Rank: game.Three, Suit: game.Spades, Forced: true},
+	}})
+	m = updated.(TUIModel)
+	if !m.isCardSelected(1) {
+		t.Fatalf("forced card should be selected when dealt")
+	}
+
+	m.toggleCardSelection(1)
+	if !m.isCardSelected(1) {
+		t.Fatalf("forced card should stay selected")
+	}
+}
+
+// TestBlindSelectModeRendersPreview verifies the blind select screen shows targets, tags and boss.
+func TestBlindSelectModeRendersPreview(t *testing.T) {
+	tag := game.Tag{Type: game.D6Tag}
+	boss := game.Boss{Name: "The Wall", Description: "Extra large blind"}
+	m := TUIModel{}
+	updated, _ := m.Update(blindSelectionMsg{
+		Ante:    1,
+		Current: game.SmallBlind,
+		Blinds: []game.BlindPreview{
+			{Blind: game.SmallBlind, Target: 300, Tag: &tag},
+			{Blind: game.BigBlind, Target: 450, Tag: &tag},
+			{Blind: game.BossBlind, Target: 1200, Reward: 5, Boss: &boss},
+		},
+	})
+	m = updated.(TUIModel)
+
+	if _, ok := m.mode.(BlindSelectMode); !ok {
+		t.Fatalf("expected BlindSelectMode, got %T", m.mode)
+	}
+	content := m.mode.renderContent(m)
+	for _, want := range []string{"Target: 300", "Reward: $5", "D6 Tag", "The Wall", "Extra large blind"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected blind preview to contain %q", want)
+		}
+	}
+}
+
+func TestRewardBreakdownShowsInterest(t *testing.T) {
+	got := rewardBreakdown(game.BlindDefeatedEvent{BaseReward: 4, Interest: 3, TotalReward: 7})
+	if !strings.Contains(got, "Interest $3") || !strings.Contains(got, "$7") {
+		t.Fatalf("expected interest in the reward breakdown, got %q", got)
+	}
+}
+
+func TestCashOutModeListsLineItems(t *testing.T) {
+	m := TUIModel{}
+	updated, _ := m.Update(blindDefeatedMsg{
+		BlindType:   game.SmallBlind,
+		TotalReward: 6,
+		NewMoney:    10,
+		Lines: []game.RewardLine{
+			{Kind: game.RewardBlind, Label: "Small Blind", Amount: 4},
+			{Kind: game.RewardJoker, Label: "The Golden Joker", Amount: 4},
+			{Kind: game.RewardRental, Label: "Rent for Landlord", Amount: -2},
+		},
+	})
+	m = updated.(TUIModel)
+
+	if _, ok := m.mode.(CashOutMode); !ok {
+		t.Fatalf("expected CashOutMode, got %T", m.mode)
+	}
+	content := m.mode.renderContent(m)
+	for _, want := range []string{"Small Blind", "The Golden Joker", "+$4", "-$2", "$6"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected cash-out to contain %q", want)
+		}
+	}
+}
+
+func TestPackModeListsCards(t *testing.T) {
+	m := TUIModel{}
+	updated, _ := m.Update(packOpenedMsg{
+		Pack:      "Arcana Pack",
+		PicksLeft: 1,
+		Cards: []game.PackCardData{
+			{Name: "The Hermit", Description: "Double your money (max $20)", Type: "tarot"},
+			{Name: "Judgement", Description: "Create a random Joker", Type: "tarot"},
+		},
+	})
+	m = updated.(TUIModel)
+
+	if _, ok := m.mode.(*PackMode); !ok {
+		t.Fatalf("expected PackMode, got %T", m.mode)
+	}
+	content := m.mode.renderContent(m)
+	for _, want := range []string{"Arcana Pack", "1. The Hermit", "2. Judgement"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected pack view to contain %q", want)
+		}
+	}
+
+	updated, _ = m.Update(packClosedMsg{Pack: "Arcana Pack"})
+	m = updated.(TUIModel)
+	if _, ok := m.mode.(*ShoppingMode); !ok || m.pack != nil {
+		t.Fatalf("expected to return to the shop, got %T", m.mode)
+	}
+}
+
+func TestShoppingModeListsVouchers(t *testing.T) {
+	grabber, _ := game.GetVoucherByName("Grabber")
+	m := TUIModel{
+		gameState: game.GameStateChangedEvent{Vouchers: []game.Voucher{grabber}},
+		shopInfo:  &game.ShopOpenedEvent{Items: []game.ShopItemData{{Name: "Wasteful", Description: "+1 discard every blind", Cost: 10, Type: "voucher"}}},
+	}
+	content := ShoppingMode{}.renderContent(m)
+	for _, want := range []string{"Vouchers: Grabber", "Wasteful (voucher)"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected shop to contain %q", want)
+		}
+	}
+}
+
+// TestShoppingModeMakeRoom verifies buying a joker with full slots asks the
+// player to sell one first and returns to the shop after the sale.
+func TestShoppingModeMakeRoom(t *testing.T) {
+	respChan := make(chan PlayerActionResponse, 1)
+	selected := 1
+	shopping := &ShoppingMode{selectedItem: &selected}
+	m := TUIModel{
+		gameState:            game.GameStateChangedEvent{Money: 10, JokerSlots: 1, Jokers: []game.OwnedJoker{{Joker: game.Joker{Name: "Old", Price: 6}, PurchasePrice: 6}}},
+		shopInfo:             &game.ShopOpenedEvent{Money: 10, Items: []game.ShopItemData{{Name: "J1", Cost: 5, Type: "joker", CanAfford: true}}},
+		mode:                 shopping,
+		actionRequestPending: &PlayerActionRequest{ResponseChan: respChan},
+	}
+
+	model, _ := m.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	m = *(model.(*TUIModel))
+	makeRoom, ok := m.mode.(*JokerOrderMode)
+	if !ok || makeRoom.makeRoomFor != "J1" {
+		t.Fatalf("expected a make-room joker mode, got %T", m.mode)
+	}
+	if !strings.Contains(makeRoom.renderContent(m), "Sell a joker to make room for J1") {
+		t.Errorf("expected the make-room prompt")
+	}
+
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	m = *(model.(*TUIModel))
+	model, _ = m.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = *(model.(*TUIModel))
+	resp := <-respChan
+	if resp.Action != game.PlayerActionSellJoker || resp.Params[0] != "1" {
+		t.Fatalf("unexpected sell response: %+v", resp)
+	}
+	if m.mode != shopping {
+		t.Fatalf("expected to return to the shop after selling, got %T", m.mode)
+	}
+}
+
+// TestJokerOrderModeShowsSellValues verifies owned jokers list what they
+// sell for in the joker screen and the shop.
+func TestJokerOrderModeShowsSellValues(t *testing.T) {
+	m := TUIModel{
+		gameState: game.GameStateChangedEvent{Jokers: []game.OwnedJoker{{Joker: game.Joker{Name: "Egg", Price: 4}, PurchasePrice: 4, SellBonus: 6}}},
+		shopInfo:  &game.ShopOpenedEvent{},
+	}
+	if content := NewJokerOrderMode(nil).renderContent(m); !strings.Contains(content, "Egg") || !strings.Contains(content, "(sells for $8)") {
+		t.Errorf("expected the joker screen to show Egg selling for $8")
+	}
+	if content := (ShoppingMode{}).renderContent(m); !strings.Contains(content, "(sells for $8)") {
+		t.Errorf("expected the shop to show Egg selling for $8")
+	}
+}
+
+// TestRenderOwnedJokerShowsCounter verifies scaling jokers show their
+// current bonus.
+func TestRenderOwnedJokerShowsCounter(t *testing.T) {
+	joker := game.OwnedJoker{
+		Joker: game.Joker{
+			Name:         "Green Joker",
+			Description:  "+1 Mult per hand played, -1 Mult per discard",
+			Effects:      []game.JokerEffectConfig{{Effect: game.AddMult}},
+			CounterRules: &game.JokerCounter{},
+		},
+		Counter: 12,
+	}
+	if line := renderOwnedJoker(joker); !strings.Contains(line, "(currently +12 Mult)") {
+		t.Errorf("line = %q, want the current bonus", line)
+	}
+}
@@ -13,14 +13,27 @@ func main() {
 	seed := flag.Int64("seed", 0, "Set random seed for reproducible gameplay (0 for random)")
 	load := flag.String("load", "", "Load game state from JSON file")
 	tui := flag.Bool("tui", false, "Run in TUI mode instead of console mode")
+	deck := flag.String("deck", "", "Starting deck to use (e.g. \"Red Deck\"); TUI mode asks when omitted")
+	stakeName := flag.String("stake", "", "Stake difficulty from White to Gold (default White)")
 	flag.Parse()
 
+	stake, err := game.ParseStake(*stakeName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		fmt.Println("Available stakes:")
+		for _, s := range game.AllStakes() {
+			fmt.Printf("  %s - %s\n", s, s.Description())
+		}
+		return
+	}
+	runConfig := game.RunConfig{Deck: *deck, Stake: stake}
+
 	// Run in TUI mode or console mode
 	if *tui {
 		if *load != "" {
 			fmt.Println("Load flag currently only supported in console mode")
 		}
-		if err := ui.RunTUI(); err != nil {
+		if err := ui.RunTUI(runConfig); err != nil {
 			fmt.Printf("Error running TUI: %v\n", err)
 		}
 	} else {
@@ -28,7 +41,6 @@ func main() {
 		eventHandler := game.NewLoggerEventHandler()
 
 		var g *game.Game
-		var err error
 
 		if *load != "" {
 			g, err = game.LoadGameFromFile(*load, eventHandler)
@@ -39,12 +51,23 @@ func main() {
 			if *seed != 0 {
 				fmt.Println("Seed flag ignored when loading game")
 			}
+			if *deck != "" || *stakeName != "" {
+				fmt.Println("Deck and stake flags ignored when loading game")
+			}
 		} else {
 			if *seed != 0 {
 				game.SetSeed(*seed)
 				fmt.Printf("Using seed: %d\n", *seed)
 			}
-			g = game.NewGame(eventHandler)
+			g, err = game.NewGameWithConfig(eventHandler, runConfig)
+			if err != nil {
+				fmt.Printf("Error starting game: %v\n", err)
+				fmt.Println("Available decks:")
+				for _, d := range game.GetAvailableDecks() {
+					fmt.Printf("  %s - %s\n", d.Name, d.Description)
+				}
+				return
+			}
 		}
 
 		// Run the game